@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"rabbit-mq-with-go/internal/monitor"
+	"rabbit-mq-with-go/internal/rabbitmq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// managementBindingLister Management API로 큐 바인딩을 조회하는 BindingLister
+func managementBindingLister(mon *monitor.RabbitMQMonitor, vhost string) rabbitmq.BindingLister {
+	return func(queueName string) ([]rabbitmq.Binding, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		infos, err := mon.ListQueueBindings(ctx, vhost, queueName)
+		if err != nil {
+			return nil, err
+		}
+
+		bindings := make([]rabbitmq.Binding, 0, len(infos))
+		for _, info := range infos {
+			if info.Source == "" {
+				continue // 기본 exchange 바인딩은 해제할 수 없음
+			}
+			bindings = append(bindings, rabbitmq.Binding{
+				Exchange:   info.Source,
+				RoutingKey: info.RoutingKey,
+				Args:       amqp.Table(info.Arguments),
+			})
+		}
+		return bindings, nil
+	}
+}
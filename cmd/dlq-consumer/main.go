@@ -1,13 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 	"syscall"
+	"time"
 
-	"rabbit-mq-with-go/internal/models"
+	"rabbit-mq-with-go/internal/alert"
 	"rabbit-mq-with-go/internal/rabbitmq"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -19,8 +25,21 @@ const (
 )
 
 func main() {
-	log.Println("[💀 DLQ Consumer 시작]")
-	log.Println("실패한 메시지들을 처리합니다...")
+	processor := flag.Bool("processor", false, "DLQ processor 모드 (주기적으로 검사해 오래된 메시지를 parking-lot으로 이동)")
+	parkingQueue := flag.String("parking-queue", "orders.parking-lot", "parking-lot 큐")
+	maxAge := flag.Duration("max-age", 24*time.Hour, "dead-letter 된 지 이 시간이 지나면 parking-lot으로 이동 (0이면 검사 안 함)")
+	maxDeaths := flag.Int("max-deaths", 3, "누적 dead-letter 횟수가 이 값 이상이면 parking-lot으로 이동 (0이면 검사 안 함)")
+	interval := flag.Duration("interval", time.Minute, "processor 모드 검사 주기")
+	archiveDir := flag.String("archive", "dead-letters", "dead letter 보관 디렉터리 (빈 값이면 보관 안 함)")
+	alertLog := flag.String("alert-log", "dlq-alerts.log", "알림 로그 파일 (빈 값이면 사용 안 함)")
+	alertWebhook := flag.String("alert-webhook", "", "알림 웹훅 URL")
+	smtpAddr := flag.String("smtp-addr", "", "알림 메일 SMTP 서버 host:port (계정은 SMTP_USERNAME, SMTP_PASSWORD 환경 변수)")
+	smtpFrom := flag.String("smtp-from", "", "알림 메일 보내는 사람")
+	smtpTo := flag.String("smtp-to", "", "알림 메일 받는 사람, 쉼표로 구분")
+	alertThreshold := flag.Int("alert-threshold", 10, "집계 구간 안에 이 건수를 넘으면 알림")
+	alertWindow := flag.Duration("alert-window", 5*time.Minute, "알림 집계 구간")
+	adminAddr := flag.String("admin-addr", "127.0.0.1:8082", "관리 API 주소 (pause/resume/prefetch, 외부에 열려면 ADMIN_TOKEN 환경 변수로 토큰 설정)")
+	flag.Parse()
 
 	// RabbitMQ 연결
 	conn, err := rabbitmq.NewConnection(rabbitURL)
@@ -29,6 +48,53 @@ func main() {
 	}
 	defer conn.Close()
 
+	// dead letter 보관소 (NDJSON 파일)
+	var archive rabbitmq.DeadLetterArchive
+	if *archiveDir != "" {
+		ndjson, err := rabbitmq.OpenNDJSONArchive(*archiveDir)
+		if err != nil {
+			log.Fatalf("보관소 열기 실패: %v", err)
+		}
+		defer ndjson.Close()
+		archive = ndjson
+	}
+
+	// dead letter 알림 (원본 큐별로 집계해 한 번만 알림)
+	sink, closeSink := newAlertSink(*alertLog, *alertWebhook, *smtpAddr, *smtpFrom, *smtpTo)
+	defer closeSink()
+
+	var alerter *rabbitmq.DeadLetterAlerter
+	if sink != nil {
+		alerter, err = rabbitmq.NewDeadLetterAlerter(sink, rabbitmq.DeadLetterRule{
+			Name:      "orders-dead-letters",
+			Queue:     "orders.queue",
+			Threshold: *alertThreshold,
+			Window:    *alertWindow,
+		})
+		if err != nil {
+			log.Fatalf("알림 설정 실패: %v", err)
+		}
+	}
+
+	if *processor {
+		config := rabbitmq.ParkingConfig{
+			Queue:        dlqQueue,
+			ParkingQueue: *parkingQueue,
+			MaxAge:       *maxAge,
+			MaxDeaths:    *maxDeaths,
+			Interval:     *interval,
+			Archive:      archive,
+		}
+		if alerter != nil && archive != nil {
+			config.OnDeadLetter = alerter.Observe
+		}
+		runProcessor(conn, config)
+		return
+	}
+
+	log.Println("[💀 DLQ Consumer 시작]")
+	log.Println("실패한 메시지들을 처리합니다...")
+
 	// DLQ Consumer 생성 (DLQ는 이미 생성되어 있으므로 간단히 구성)
 	consumer, err := rabbitmq.NewConsumer(conn, rabbitmq.ConsumerConfig{
 		QueueName:     dlqQueue,
@@ -37,6 +103,15 @@ func main() {
 	if err != nil {
 		log.Fatalf("DLQ Consumer 생성 실패: %v", err)
 	}
+	if archive != nil {
+		consumer.Use(rabbitmq.ArchiveDeadLetters(archive, dlqQueue))
+	}
+	if alerter != nil {
+		consumer.Use(alerter.Middleware())
+	}
+
+	// 관리 API (일시 중지/재개/prefetch 변경)
+	rabbitmq.ServeAdmin(*adminAddr, os.Getenv("ADMIN_TOKEN"), consumer)
 
 	// Graceful Shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -45,6 +120,10 @@ func main() {
 	go func() {
 		<-sigChan
 		log.Println("\n[🛑] DLQ Consumer 종료 중...")
+		if archive != nil {
+			archive.Close()
+		}
+		closeSink()
 		conn.Close()
 		os.Exit(0)
 	}()
@@ -56,39 +135,156 @@ func main() {
 	}
 }
 
+// newAlertSink 설정된 알림 Sink를 묶는다 (하나도 없으면 nil)
+func newAlertSink(logPath, webhookURL, smtpAddr, smtpFrom, smtpTo string) (alert.Sink, func()) {
+	var sinks []alert.Sink
+	closeSink := func() {}
+
+	if logPath != "" {
+		fileSink, err := alert.OpenLogFileSink(logPath)
+		if err != nil {
+			log.Fatalf("알림 로그 파일 열기 실패: %v", err)
+		}
+		sinks = append(sinks, fileSink)
+		closeSink = func() { fileSink.Close() }
+	}
+	if webhookURL != "" {
+		sinks = append(sinks, alert.NewWebhookSink(webhookURL, nil))
+	}
+	if smtpAddr != "" {
+		recipients := splitRecipients(smtpTo)
+		if len(recipients) == 0 {
+			log.Fatal("-smtp-addr를 사용하려면 -smtp-to에 받는 사람이 필요합니다")
+		}
+		mailSink, err := alert.NewSMTPSink(alert.SMTPConfig{
+			Addr:     smtpAddr,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     smtpFrom,
+			To:       recipients,
+		})
+		if err != nil {
+			log.Fatalf("메일 알림 설정 실패: %v", err)
+		}
+		sinks = append(sinks, mailSink)
+	}
+
+	if len(sinks) == 0 {
+		return nil, closeSink
+	}
+	return alert.Multi(sinks...), closeSink
+}
+
+// splitRecipients 쉼표로 구분된 받는 사람 목록 (공백 제거, 빈 항목 제외)
+func splitRecipients(list string) []string {
+	var recipients []string
+	for _, addr := range strings.Split(list, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			recipients = append(recipients, addr)
+		}
+	}
+	return recipients
+}
+
+// runProcessor DLQ를 소비하지 않고 주기적으로 검사만 한다 (남은 메시지는 dlq-replay로 재처리)
+func runProcessor(conn *rabbitmq.Connection, config rabbitmq.ParkingConfig) {
+	log.Println("[🧹 DLQ Processor 시작]")
+	log.Printf("  %s → %s (max-age: %s, max-deaths: %d)",
+		config.Queue, config.ParkingQueue, config.MaxAge, config.MaxDeaths)
+
+	processor, err := rabbitmq.NewDeadLetterProcessor(conn, config)
+	if err != nil {
+		log.Fatalf("DLQ Processor 생성 실패: %v", err)
+	}
+	defer processor.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	processor.Run(ctx)
+	log.Println("[🛑] DLQ Processor 종료")
+}
+
+// handleDeadLetter schema_name 헤더로 이벤트 종류를 구분해 어떤 메시지든 출력
 func handleDeadLetter(delivery amqp.Delivery) error {
-	var order models.OrderEvent
-	if err := json.Unmarshal(delivery.Body, &order); err != nil {
-		log.Printf("[❌] 메시지 파싱 실패: %v", err)
-		return nil // 파싱 실패는 재시도해도 의미 없으므로 ACK
+	schemaName, _ := delivery.Headers[rabbitmq.HeaderSchemaName].(string)
+	if schemaName == "" {
+		schemaName = "(schema_name 없음)"
 	}
 
 	log.Println("╔════════════════════════════════════════╗")
 	log.Println("║         💀 Dead Letter 수신            ║")
 	log.Println("╚════════════════════════════════════════╝")
-	log.Printf("  Order ID: %s", order.OrderID)
-	log.Printf("  Customer: %s", order.CustomerID)
-	log.Printf("  Amount: %.0f", order.Amount)
-	log.Printf("  Status: %s", order.Status)
-
-	// x-death 헤더에서 실패 정보 추출
-	if xDeath, ok := delivery.Headers["x-death"]; ok {
-		deaths := xDeath.([]interface{})
-		for _, death := range deaths {
-			deathInfo := death.(amqp.Table)
-			log.Printf("  실패 횟수: %d", deathInfo["count"])
-			log.Printf("  원인: %s", deathInfo["reason"])
-			log.Printf("  원본 큐: %s", deathInfo["queue"])
+	log.Printf("  Schema: %s", schemaName)
+	if version, ok := delivery.Headers[rabbitmq.HeaderSchemaVersion]; ok {
+		log.Printf("  Version: %v", version)
+	}
+	if delivery.MessageId != "" {
+		log.Printf("  Message ID: %s", delivery.MessageId)
+	}
+
+	printEvent(delivery.Body)
+
+	if reason, ok := delivery.Headers[rabbitmq.HeaderError]; ok {
+		log.Printf("  에러: %v", reason)
+	}
+
+	// x-death 헤더에서 실패 정보 추출 (Consumer가 직접 재발행한 경우에는 x-original-* 헤더)
+	deaths, err := rabbitmq.ParseDeaths(delivery.Headers)
+	if err != nil {
+		log.Printf("[⚠️] x-death 헤더 파싱 실패: %v", err)
+	}
+	for _, death := range deaths {
+		log.Printf("  실패 횟수: %d", death.Count)
+		log.Printf("  원인: %s", death.Reason)
+		log.Printf("  원본 큐: %s", death.Queue)
+		log.Printf("  원본 목적지: %s %v", death.Exchange, death.RoutingKeys)
+		if !death.Time.IsZero() {
+			log.Printf("  시각: %s", death.Time.Format(time.RFC3339))
+		}
+	}
+	if len(deaths) == 0 {
+		if queue, ok := delivery.Headers[rabbitmq.HeaderOriginalQueue]; ok {
+			log.Printf("  원본 큐: %v", queue)
+			log.Printf("  원본 목적지: %v [%v]",
+				delivery.Headers[rabbitmq.HeaderOriginalExchange], delivery.Headers[rabbitmq.HeaderOriginalRoutingKey])
 		}
 	}
 
 	log.Println("─────────────────────────────────────────")
 
 	// 여기서 실패한 메시지에 대한 처리 수행:
-	// 1. DB에 실패 기록 저장
-	// 2. 알림 발송 (Slack, 이메일 등)
-	// 3. 수동 재처리를 위한 대기열에 추가
+	// 1. 실패 기록 보관 → ArchiveDeadLetters 미들웨어 (NDJSON 파일)
+	// 2. 알림 발송 → DeadLetterAlerter 미들웨어 (웹훅, 메일, 로그 파일)
+	// 3. 수동 재처리 (문제 해결 후 cmd/dlq-replay로 원래 목적지에 재발행)
 	// 4. 메트릭 수집
 
 	return nil
 }
+
+// printEvent 이벤트 본문을 필드 이름 순서로 출력 (JSON이 아니면 원문 그대로)
+func printEvent(body []byte) {
+	var event map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil {
+		log.Printf("  Body: %s", string(body))
+		return
+	}
+
+	fields := make([]string, 0, len(event))
+	for field := range event {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		switch v := event[field].(type) {
+		case map[string]interface{}, []interface{}:
+			formatted, _ := json.Marshal(v)
+			log.Printf("  %s: %s", field, formatted)
+		default:
+			log.Printf("  %s: %v", field, v)
+		}
+	}
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"rabbit-mq-with-go/internal/monitor"
@@ -18,6 +24,30 @@ const (
 )
 
 func main() {
+	watch := flag.Bool("watch", false, "전체 화면 대시보드 모드 (자동 갱신, 큐 정렬, 메시지 보기, purge)")
+	exporterAddr := flag.String("exporter", "", "Prometheus exporter 모드로 실행할 주소 (예: :9419, 빈 값이면 데모 실행)")
+	interval := flag.Duration("interval", 15*time.Second, "exporter, 큐 샘플 수집 주기 (watch 모드는 2s)")
+	rulesPath := flag.String("rules", "", "알림 규칙 YAML 파일 (예: cmd/monitor/alert-rules.yaml, 빈 값이면 규칙 평가 안 함)")
+	historyPath := flag.String("history", "", "큐 샘플 보관 파일 (예: monitor-history.db, 빈 값이면 메모리에만 보관)")
+	historySize := flag.Int("history-size", 360, "큐별 보관 샘플 수")
+	flag.Parse()
+
+	if *watch {
+		refresh := 2 * time.Second
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "interval" {
+				refresh = *interval
+			}
+		})
+		runWatch(refresh)
+		return
+	}
+
+	if *exporterAddr != "" || *rulesPath != "" {
+		runDaemon(*exporterAddr, *interval, *rulesPath, *historyPath, *historySize)
+		return
+	}
+
 	fmt.Println("╔════════════════════════════════════════════════════════════╗")
 	fmt.Println("║          RabbitMQ Monitor & Schema Registry Demo           ║")
 	fmt.Println("╚════════════════════════════════════════════════════════════╝")
@@ -67,14 +97,25 @@ func demoRabbitMQMonitor() {
 
 	mon := monitor.NewRabbitMQMonitor(rabbitMQURL, username, password)
 
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
 	// Overview 조회
 	fmt.Println("\n1. 전체 개요 (Overview)")
-	overview, err := mon.GetOverview()
+	overview, err := mon.GetOverview(ctx)
 	if err != nil {
-		log.Printf("Overview 조회 실패 (RabbitMQ가 실행 중인지 확인하세요): %v", err)
-		fmt.Println("   ⚠️  RabbitMQ Management UI에 접속할 수 없습니다.")
-		fmt.Println("   다음 명령으로 RabbitMQ를 시작하세요:")
-		fmt.Println("   $ docker-compose up -d")
+		log.Printf("Overview 조회 실패: %v", err)
+		switch {
+		case errors.Is(err, monitor.ErrUnauthorized):
+			fmt.Println("   ⚠️  Management API 인증에 실패했습니다.")
+			fmt.Println("   계정(username/password)과 management 태그 권한을 확인하세요.")
+		case errors.Is(err, monitor.ErrNotFound):
+			fmt.Println("   ⚠️  Management API 경로를 찾을 수 없습니다. 주소를 확인하세요.")
+		default:
+			fmt.Println("   ⚠️  RabbitMQ Management UI에 접속할 수 없습니다.")
+			fmt.Println("   다음 명령으로 RabbitMQ를 시작하세요:")
+			fmt.Println("   $ docker-compose up -d")
+		}
 		return
 	}
 
@@ -94,7 +135,7 @@ func demoRabbitMQMonitor() {
 
 	// Exchange 목록
 	fmt.Println("\n2. Exchange 목록")
-	exchanges, err := mon.ListExchanges()
+	exchanges, err := mon.ListExchanges(ctx)
 	if err != nil {
 		log.Printf("Exchange 조회 실패: %v", err)
 	} else {
@@ -111,7 +152,7 @@ func demoRabbitMQMonitor() {
 
 	// Queue 목록
 	fmt.Println("\n3. Queue 목록")
-	queues, err := mon.ListQueues()
+	queues, err := mon.ListQueues(ctx)
 	if err != nil {
 		log.Printf("Queue 조회 실패: %v", err)
 	} else {
@@ -128,7 +169,7 @@ func demoRabbitMQMonitor() {
 
 	// Binding 목록
 	fmt.Println("\n4. Binding 목록")
-	bindings, err := mon.ListBindings()
+	bindings, err := mon.ListBindings(ctx)
 	if err != nil {
 		log.Printf("Binding 조회 실패: %v", err)
 	} else {
@@ -141,7 +182,180 @@ func demoRabbitMQMonitor() {
 		}
 	}
 
+	// DLQ 메시지 미리보기 (소비하지 않음)
+	fmt.Println("\n5. DLQ 메시지 미리보기 (orders.dlq)")
+	messages, err := mon.GetMessages(ctx, "/", "orders.dlq", monitor.GetMessagesOptions{
+		Count:    3,
+		AckMode:  monitor.AckRequeue,
+		Truncate: 200,
+	})
+	if errors.Is(err, monitor.ErrNotFound) {
+		fmt.Println("   (orders.dlq 큐가 없습니다)")
+	} else if err != nil {
+		log.Printf("메시지 조회 실패: %v", err)
+	} else {
+		if len(messages) == 0 {
+			fmt.Println("   (대기 중인 메시지가 없습니다)")
+		}
+		for _, msg := range messages {
+			body, _ := msg.Body()
+			suffix := ""
+			if msg.Truncated() {
+				suffix = fmt.Sprintf(" ... (%d bytes)", msg.PayloadBytes)
+			}
+			fmt.Printf("   • [%s] %s%s\n", msg.RoutingKey, body, suffix)
+			if msg.Properties.MessageID != "" {
+				fmt.Printf("     message_id: %s\n", msg.Properties.MessageID)
+			}
+		}
+	}
+
+	// 연결별 unacked 메시지 (메시지를 붙잡고 있는 클라이언트 찾기)
+	fmt.Println("\n6. 연결별 Unacked 메시지")
+	connections, err := mon.ListConnections(ctx)
+	if err != nil {
+		log.Printf("연결 목록 조회 실패: %v", err)
+	}
+	clientNames := make(map[string]string)
+	for _, c := range connections {
+		clientNames[c.Name] = c.ClientName()
+	}
+	unacked, err := mon.UnackedByConnection(ctx)
+	if err != nil {
+		log.Printf("채널 목록 조회 실패: %v", err)
+	} else {
+		if len(unacked) == 0 {
+			fmt.Println("   (연결된 클라이언트가 없습니다)")
+		}
+		for _, u := range unacked {
+			client := clientNames[u.Connection.Name]
+			if client == "" {
+				client = "(connection_name 없음)"
+			}
+			fmt.Printf("   • %s:%d %s - unacked: %d (채널 %d개)\n",
+				u.Connection.PeerHost, u.Connection.PeerPort, client, u.Unacked, u.Channels)
+		}
+	}
+	if consumers, err := mon.ListConsumers(ctx); err == nil {
+		for _, c := range consumers {
+			fmt.Printf("   └ %s ← %s (prefetch: %d, %s)\n",
+				c.Queue.Name, c.ConsumerTag, c.PrefetchCount, c.Channel.PeerHost)
+		}
+	}
+
+	// 클러스터 상태 (노드 리소스, health check)
+	fmt.Println("\n7. 클러스터 상태")
+	printClusterHealth(ctx, mon)
+
 	fmt.Println("\n" + strings.Repeat("─", 60))
 	fmt.Println("💡 Management UI: http://localhost:15672 (guest/guest)")
 	fmt.Printf("   현재 시간: %s\n", time.Now().Format("2006-01-02 15:04:05"))
 }
+
+// printClusterHealth 노드별 리소스 사용량과 health check 결과 출력
+// watermark에 근접한 노드는 publisher가 차단되기 전에 경고로 표시한다.
+func printClusterHealth(ctx context.Context, mon *monitor.RabbitMQMonitor) {
+	health, err := mon.GetClusterHealth(ctx, monitor.DefaultHealthThresholds)
+	if err != nil {
+		log.Printf("클러스터 상태 조회 실패: %v", err)
+		return
+	}
+
+	for _, node := range health.Nodes {
+		status := "✅"
+		if _, warn := health.Warnings[node.Name]; warn {
+			status = "⚠️ "
+		}
+		fmt.Printf("   %s %s (uptime: %s)\n", status, node.Name, node.FormatUptime())
+		fmt.Printf("      메모리: watermark의 %.0f%%, 디스크 여유: watermark의 %.1f배\n",
+			node.MemoryUsage()*100, node.DiskHeadroom())
+		fmt.Printf("      FD: %d/%d, 소켓: %d/%d, 프로세스: %d/%d\n",
+			node.FDUsed, node.FDTotal, node.SocketsUsed, node.SocketsTotal, node.ProcUsed, node.ProcTotal)
+		for _, warning := range health.Warnings[node.Name] {
+			fmt.Printf("      ⚠️  %s\n", warning)
+		}
+	}
+
+	for _, check := range health.Checks {
+		if check.OK {
+			fmt.Printf("   ✅ health check %s: ok\n", check.Name)
+		} else {
+			fmt.Printf("   ❌ health check %s: %s\n", check.Name, check.Reason)
+		}
+	}
+	if health.Healthy() {
+		fmt.Println("   클러스터 상태 정상")
+	}
+}
+
+// runDaemon 큐 샘플러와 exporter, 알림 규칙 평가(지정한 것만)를 Ctrl+C까지 실행
+func runDaemon(exporterAddr string, interval time.Duration, rulesPath, historyPath string, historySize int) {
+	mon := monitor.NewRabbitMQMonitor(rabbitMQURL, username, password)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// 큐 목록은 샘플러만 Management API로 수집하고, exporter와 알림 규칙은 그 결과를 함께 쓴다
+	config := monitor.SamplerConfig{Interval: interval, Capacity: historySize}
+	if historyPath != "" {
+		store, err := monitor.OpenBoltSampleStore(historyPath)
+		if err != nil {
+			log.Fatalf("샘플 저장소 열기 실패: %v", err)
+		}
+		defer store.Close()
+		config.Store = store
+	}
+	sampler, err := monitor.NewSampler(mon, config)
+	if err != nil {
+		log.Fatalf("샘플러 생성 실패: %v", err)
+	}
+	go sampler.Run(ctx)
+
+	if rulesPath != "" {
+		rules, err := monitor.LoadRulesConfig(rulesPath)
+		if err != nil {
+			log.Fatalf("알림 규칙 로드 실패: %v", err)
+		}
+		engine, closeNotifiers, err := monitor.NewRuleEngineFromConfig(sampler, rules)
+		if err != nil {
+			log.Fatalf("알림 규칙 엔진 생성 실패: %v", err)
+		}
+		defer closeNotifiers()
+
+		log.Printf("[🔔] 알림 규칙 %d개 평가 시작 (%s)", len(rules.Rules), rulesPath)
+		if exporterAddr == "" {
+			engine.Run(ctx)
+			log.Println("[🛑] 알림 규칙 평가 종료")
+			return
+		}
+		go engine.Run(ctx)
+	}
+
+	runExporter(ctx, mon, sampler, exporterAddr, interval)
+}
+
+// runExporter /metrics와 큐 이력/추세 API 제공
+func runExporter(ctx context.Context, mon *monitor.RabbitMQMonitor, sampler *monitor.Sampler, addr string, interval time.Duration) {
+	exporter := monitor.NewExporter(mon, monitor.ExporterConfig{Interval: interval, Queues: sampler})
+	go exporter.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	mux.Handle("/api/", http.StripPrefix("/api", sampler.Handler()))
+	server := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("[📈] Prometheus exporter 시작: http://localhost%s/metrics (수집 주기: %s)", addr, interval)
+	log.Printf("     큐 추세: http://localhost%s/api/trends?window=5m", addr)
+	log.Printf("     큐 이력: http://localhost%s/api/history?vhost=/&queue=orders.queue", addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("exporter 서버 실패: %v", err)
+	}
+	log.Println("[🛑] Prometheus exporter 종료")
+}
@@ -0,0 +1,716 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"rabbit-mq-with-go/internal/monitor"
+
+	"golang.org/x/term"
+)
+
+// ANSI 제어 코드
+const (
+	altScreenOn  = "\x1b[?1049h"
+	altScreenOff = "\x1b[?1049l"
+	cursorHide   = "\x1b[?25l"
+	cursorShow   = "\x1b[?25h"
+	cursorHome   = "\x1b[H"
+	clearLine    = "\x1b[K"
+	clearBelow   = "\x1b[J"
+	styleReset   = "\x1b[0m"
+	styleBold    = "\x1b[1m"
+	styleDim     = "\x1b[2m"
+	styleReverse = "\x1b[7m"
+	styleDLQ     = "\x1b[1;31m" // 메시지가 있는 DLQ
+)
+
+// 추세 계산 구간과 sparkline 길이
+const (
+	watchTrendWindow = time.Minute
+	sparkWidth       = 20
+)
+
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+// sortColumn 큐 테이블 정렬 기준
+type sortColumn int
+
+const (
+	sortName sortColumn = iota
+	sortMessages
+	sortPublish
+	sortAck
+	sortConsumers
+)
+
+var sortColumnNames = []string{"이름", "메시지 수", "publish 속도", "ack 속도", "consumer 수"}
+
+// watchMode 화면 상태
+type watchMode int
+
+const (
+	modeTable watchMode = iota
+	modePeek
+	modeConfirmPurge
+)
+
+// watchUpdate 백그라운드 작업 결과를 이벤트 루프에서 모델에 반영하는 함수
+type watchUpdate func(m *watchModel)
+
+// queueRow 테이블 한 줄
+type queueRow struct {
+	key   monitor.QueueKey
+	last  monitor.QueueSample
+	trend monitor.QueueTrend
+	spark []float64
+}
+
+// watchModel 대시보드 상태
+type watchModel struct {
+	mon      *monitor.RabbitMQMonitor
+	sampler  *monitor.Sampler
+	interval time.Duration
+
+	rows     []queueRow
+	sortBy   sortColumn
+	asc      bool
+	selected monitor.QueueKey // 정렬이 바뀌어도 선택 유지
+	offset   int              // 화면에 보이는 첫 줄
+
+	mode     watchMode
+	peek     []monitor.QueueMessage
+	peekKey  monitor.QueueKey
+	peekErr  error
+	peekWarn string // 조회로 생긴 부작용 안내 (quorum 큐의 전달 횟수 증가 등)
+	status   string
+	lastErr  error
+	updated  time.Time
+
+	// Management API 호출은 goroutine에서 실행하고 결과만 updates로 받아 이벤트 루프가 멈추지 않게 한다
+	updates    chan watchUpdate
+	refreshing bool // 샘플 수집 중 (겹쳐서 수집하지 않음)
+	pending    bool // peek 또는 purge 실행 중
+}
+
+// runWatch 전체 화면 대시보드 (q 또는 Ctrl+C로 종료)
+func runWatch(interval time.Duration) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Fprintln(os.Stderr, "watch 모드는 터미널에서만 실행할 수 있습니다")
+		os.Exit(1)
+	}
+
+	mon := monitor.NewRabbitMQMonitor(rabbitMQURL, username, password)
+	sampler, err := monitor.NewSampler(mon, monitor.SamplerConfig{Interval: interval})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "샘플러 생성 실패: %v\n", err)
+		os.Exit(1)
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "터미널 설정 실패: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(altScreenOn + cursorHide)
+	defer func() {
+		fmt.Print(cursorShow + altScreenOff)
+		term.Restore(fd, oldState)
+	}()
+
+	// raw 모드에서 Ctrl+C는 키 입력으로 들어오고, SIGTERM만 시그널로 받는다
+	// 종료하면 stop이 ctx를 취소해 진행 중인 백그라운드 조회도 멈춘다
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	keys := make(chan string)
+	go readKeys(keys)
+
+	m := &watchModel{mon: mon, sampler: sampler, interval: interval, sortBy: sortMessages, updates: make(chan watchUpdate)}
+	m.refresh(ctx)
+	m.render()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.refresh(ctx)
+		case update := <-m.updates:
+			update(m)
+		case key, ok := <-keys:
+			if !ok || !m.handleKey(ctx, key) {
+				return
+			}
+		}
+		m.render()
+	}
+}
+
+// readKeys 표준 입력을 키 이름으로 변환 (방향키는 "up", "down")
+func readKeys(keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+		input := string(buf[:n])
+		switch input {
+		case "\x1b[A", "\x1bOA":
+			keys <- "up"
+		case "\x1b[B", "\x1bOB":
+			keys <- "down"
+		case "\x1b[5~":
+			keys <- "pgup"
+		case "\x1b[6~":
+			keys <- "pgdown"
+		case "\x1b":
+			keys <- "esc"
+		case "\x03":
+			keys <- "ctrl-c"
+		case "\r", "\n":
+			keys <- "enter"
+		default:
+			if !strings.HasPrefix(input, "\x1b") {
+				for _, r := range input {
+					keys <- string(r)
+				}
+			}
+		}
+	}
+}
+
+// run fn을 goroutine에서 실행하고 결과 반영 함수를 이벤트 루프로 보낸다
+func (m *watchModel) run(ctx context.Context, fn func(ctx context.Context) watchUpdate) {
+	go func() {
+		update := fn(ctx)
+		select {
+		case m.updates <- update:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// refresh 백그라운드에서 샘플 하나 수집 후 테이블 다시 구성 (이전 수집이 끝나지 않았으면 건너뜀)
+func (m *watchModel) refresh(ctx context.Context) {
+	if m.refreshing {
+		return
+	}
+	m.refreshing = true
+
+	m.run(ctx, func(ctx context.Context) watchUpdate {
+		ctx, cancel := context.WithTimeout(ctx, m.interval)
+		defer cancel()
+
+		if err := m.sampler.Sample(ctx); err != nil {
+			return func(m *watchModel) {
+				m.refreshing = false
+				m.lastErr = err
+			}
+		}
+		updated := time.Now()
+		rows := queueRows(m.sampler)
+		return func(m *watchModel) {
+			m.refreshing = false
+			m.lastErr = nil
+			m.updated = updated
+			m.rows = rows
+			m.sortRows()
+		}
+	})
+}
+
+// queueRows 샘플러에 쌓인 기록으로 테이블 줄 구성
+func queueRows(sampler *monitor.Sampler) []queueRow {
+	var rows []queueRow
+	for _, key := range sampler.Queues() {
+		history := sampler.History(key, 0)
+		if len(history) == 0 {
+			continue
+		}
+		trend, _ := sampler.Trend(key, watchTrendWindow)
+
+		if len(history) > sparkWidth {
+			history = history[len(history)-sparkWidth:]
+		}
+		spark := make([]float64, len(history))
+		for i, s := range history {
+			spark[i] = float64(s.Messages)
+		}
+		rows = append(rows, queueRow{key: key, last: history[len(history)-1], trend: trend, spark: spark})
+	}
+	return rows
+}
+
+func (m *watchModel) sortRows() {
+	less := func(a, b queueRow) bool {
+		switch m.sortBy {
+		case sortMessages:
+			return a.last.Messages < b.last.Messages
+		case sortPublish:
+			return a.trend.PublishRate < b.trend.PublishRate
+		case sortAck:
+			return a.trend.AckRate < b.trend.AckRate
+		case sortConsumers:
+			return a.last.Consumers < b.last.Consumers
+		}
+		return a.key.String() < b.key.String()
+	}
+	sort.SliceStable(m.rows, func(i, j int) bool {
+		if m.asc {
+			return less(m.rows[i], m.rows[j])
+		}
+		return less(m.rows[j], m.rows[i])
+	})
+}
+
+// selectedIndex 선택된 큐의 줄 번호 (없으면 첫 줄 선택)
+func (m *watchModel) selectedIndex() int {
+	for i, row := range m.rows {
+		if row.key == m.selected {
+			return i
+		}
+	}
+	if len(m.rows) > 0 {
+		m.selected = m.rows[0].key
+	}
+	return 0
+}
+
+func (m *watchModel) selectedRow() (queueRow, bool) {
+	if len(m.rows) == 0 {
+		return queueRow{}, false
+	}
+	return m.rows[m.selectedIndex()], true
+}
+
+func (m *watchModel) moveSelection(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	i := m.selectedIndex() + delta
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(m.rows) {
+		i = len(m.rows) - 1
+	}
+	m.selected = m.rows[i].key
+}
+
+// handleKey 키 입력 처리 (false면 종료)
+func (m *watchModel) handleKey(ctx context.Context, key string) bool {
+	if key == "ctrl-c" {
+		return false
+	}
+
+	switch m.mode {
+	case modePeek:
+		m.mode = modeTable
+		return true
+	case modeConfirmPurge:
+		m.mode = modeTable
+		if key == "y" || key == "Y" {
+			m.purgeSelected(ctx)
+		} else {
+			m.status = "purge 취소"
+		}
+		return true
+	}
+
+	switch key {
+	case "q":
+		return false
+	case "up", "k":
+		m.moveSelection(-1)
+	case "down", "j":
+		m.moveSelection(1)
+	case "pgup":
+		m.moveSelection(-10)
+	case "pgdown":
+		m.moveSelection(10)
+	case "s":
+		m.sortBy = (m.sortBy + 1) % sortColumn(len(sortColumnNames))
+		m.asc = m.sortBy == sortName
+		m.sortRows()
+		m.status = "정렬: " + sortColumnNames[m.sortBy]
+	case "1", "2", "3", "4", "5":
+		m.sortBy = sortColumn(key[0] - '1')
+		m.asc = m.sortBy == sortName // 이름은 오름차순, 나머지는 큰 값부터
+		m.sortRows()
+		m.status = "정렬: " + sortColumnNames[m.sortBy]
+	case "r":
+		m.asc = !m.asc
+		m.sortRows()
+	case "p", "enter":
+		m.peekSelected(ctx)
+	case "x":
+		if m.pending {
+			m.status = "이전 작업이 끝날 때까지 기다려 주세요"
+			return true
+		}
+		if row, ok := m.selectedRow(); ok {
+			m.mode = modeConfirmPurge
+			m.status = fmt.Sprintf("%s 큐의 대기 메시지 %d건을 모두 삭제합니다 (unacked 제외). 계속하려면 y (취소: 다른 키)", row.key, row.last.Ready)
+		}
+	}
+	return true
+}
+
+// peekSelected 선택한 큐의 메시지를 가져와 다시 큐에 넣는 방식(AckRequeue)으로 조회
+// 큐에서 제거하지는 않지만 redelivered로 표시되고 순서가 바뀔 수 있으며, quorum 큐는 전달 횟수가 늘어난다.
+// x-delivery-limit이 있는 quorum 큐는 조회만으로 메시지가 dead-letter되거나 삭제될 수 있어 조회하지 않는다.
+func (m *watchModel) peekSelected(ctx context.Context) {
+	row, ok := m.selectedRow()
+	if !ok {
+		return
+	}
+	if m.pending {
+		m.status = "이전 작업이 끝날 때까지 기다려 주세요"
+		return
+	}
+	m.pending = true
+	m.status = fmt.Sprintf("%s 메시지 조회 중...", row.key)
+
+	m.run(ctx, func(ctx context.Context) watchUpdate {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		info, err := m.mon.GetQueue(ctx, row.key.VHost, row.key.Name)
+		if err != nil {
+			return func(m *watchModel) {
+				m.pending = false
+				m.status = fmt.Sprintf("큐 정보 조회 실패: %v", err)
+			}
+		}
+		if limit, ok := info.DeliveryLimit(); ok {
+			return func(m *watchModel) {
+				m.pending = false
+				m.status = fmt.Sprintf("%s 큐는 x-delivery-limit=%d인 quorum 큐라 미리보기로 전달 횟수가 늘어나므로 조회하지 않습니다", row.key, limit)
+			}
+		}
+		var warn string
+		if info.IsQuorum() {
+			warn = "quorum 큐: 조회한 메시지의 전달 횟수(x-delivery-count)가 1씩 늘어납니다"
+		}
+
+		messages, err := m.mon.GetMessages(ctx, row.key.VHost, row.key.Name, monitor.GetMessagesOptions{
+			Count:    10,
+			AckMode:  monitor.AckRequeue,
+			Truncate: 500,
+		})
+		return func(m *watchModel) {
+			m.pending = false
+			m.status = ""
+			m.peekKey, m.peek, m.peekErr, m.peekWarn = row.key, messages, err, warn
+			m.mode = modePeek
+		}
+	})
+}
+
+// purgeSelected 백그라운드에서 선택한 큐를 비우고 완료되면 다시 수집
+func (m *watchModel) purgeSelected(ctx context.Context) {
+	row, ok := m.selectedRow()
+	if !ok {
+		return
+	}
+	m.pending = true
+	m.status = fmt.Sprintf("%s 큐 비우는 중...", row.key)
+
+	m.run(ctx, func(ctx context.Context) watchUpdate {
+		purgeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		change, err := m.mon.PurgeQueue(purgeCtx, row.key.VHost, row.key.Name)
+		return func(m *watchModel) {
+			m.pending = false
+			if err != nil {
+				m.status = fmt.Sprintf("purge 실패: %v", err)
+				return
+			}
+			m.status = change.String()
+			m.refresh(ctx)
+		}
+	})
+}
+
+// render 화면 전체를 다시 그림 (raw 모드라 줄바꿈은 \r\n)
+func (m *watchModel) render() {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width < 40 || height < 10 {
+		width, height = 80, 24
+	}
+
+	var lines []string
+	add := func(s string) { lines = append(lines, s) }
+
+	title := fmt.Sprintf(" RabbitMQ watch ─ %s ─ %s (갱신 주기 %s)",
+		rabbitMQURL, m.updated.Format("15:04:05"), m.interval)
+	add(styleBold + fit(title, width) + styleReset)
+	add(m.summary(width))
+	add("")
+
+	switch m.mode {
+	case modePeek:
+		lines = append(lines, m.peekLines(width, height-len(lines)-2)...)
+	default:
+		lines = append(lines, m.tableLines(width, height-len(lines)-2)...)
+	}
+
+	for len(lines) < height-2 {
+		add("")
+	}
+	status := m.status
+	if m.lastErr != nil {
+		status = "⚠️  조회 실패: " + m.lastErr.Error()
+	}
+	add(fit(status, width))
+	help := " ↑↓ 선택  s/1-5 정렬  r 역순  p 메시지 보기(재전달)  x 비우기  q 종료"
+	if m.mode == modePeek {
+		help = " 아무 키나 누르면 목록으로 돌아갑니다"
+	}
+	add(styleDim + fit(help, width) + styleReset)
+
+	var b strings.Builder
+	b.WriteString(cursorHome)
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line + clearLine)
+	}
+	b.WriteString(clearBelow)
+	os.Stdout.WriteString(b.String())
+}
+
+// summary 전체 메시지 수와 메시지가 쌓인 DLQ
+func (m *watchModel) summary(width int) string {
+	total, unacked := 0, 0
+	var dlqs []string
+	for _, row := range m.rows {
+		total += row.last.Messages
+		unacked += row.last.Unacked
+		if isDeadLetterQueue(row.key.Name) && row.last.Messages > 0 {
+			dlqs = append(dlqs, fmt.Sprintf("%s(%d)", row.key, row.last.Messages))
+		}
+	}
+	s := fmt.Sprintf(" 큐 %d개, 메시지 %d건 (unacked %d)", len(m.rows), total, unacked)
+	if len(dlqs) == 0 {
+		return fit(s, width)
+	}
+	return fit(s, width) + "  " + styleDLQ + fit("DLQ: "+strings.Join(dlqs, ", "), width-displayWidth(s)-2) + styleReset
+}
+
+func (m *watchModel) tableLines(width, height int) []string {
+	// 이름과 sparkline 외 열 너비와 간격, 좁은 터미널에서는 sparkline부터 줄인다
+	const fixed = 1 + 9*3 + 6 + 9*2 + 2 + 9
+	spark := sparkWidth
+	nameWidth := width - fixed - spark
+	if nameWidth < 12 {
+		spark = max(5, spark-(12-nameWidth))
+		nameWidth = 12
+	}
+
+	header := fmt.Sprintf(" %s %8s %8s %8s %5s %8s %8s  %s %8s",
+		pad(m.columnTitle("QUEUE", sortName), nameWidth),
+		m.columnTitle("MSGS", sortMessages), "READY", "UNACKED",
+		m.columnTitle("CONS", sortConsumers),
+		m.columnTitle("PUB/s", sortPublish), m.columnTitle("ACK/s", sortAck),
+		pad("DEPTH", spark), "DRAIN")
+	lines := []string{styleBold + fit(header, width) + styleReset}
+
+	if len(m.rows) == 0 {
+		if m.updated.IsZero() {
+			return append(lines, " (불러오는 중...)")
+		}
+		return append(lines, " (큐가 없습니다)")
+	}
+
+	// 선택한 줄이 보이도록 스크롤
+	visible := height - 1
+	if visible < 1 {
+		visible = 1
+	}
+	selected := m.selectedIndex()
+	if selected < m.offset {
+		m.offset = selected
+	}
+	if selected >= m.offset+visible {
+		m.offset = selected - visible + 1
+	}
+	if m.offset > len(m.rows)-visible {
+		m.offset = max(0, len(m.rows)-visible)
+	}
+
+	for i := m.offset; i < len(m.rows) && i < m.offset+visible; i++ {
+		row := m.rows[i]
+		values := row.spark
+		if len(values) > spark {
+			values = values[len(values)-spark:]
+		}
+		line := fmt.Sprintf(" %s %8d %8d %8d %5d %8.1f %8.1f  %s %8s",
+			pad(truncate(row.key.String(), nameWidth), nameWidth),
+			row.last.Messages, row.last.Ready, row.last.Unacked, row.last.Consumers,
+			row.trend.PublishRate, row.trend.AckRate,
+			pad(sparkline(values), spark), drainText(row.trend))
+		line = fit(line, width)
+
+		switch {
+		case i == selected:
+			line = styleReverse + line + styleReset
+		case isDeadLetterQueue(row.key.Name) && row.last.Messages > 0:
+			line = styleDLQ + line + styleReset
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// columnTitle 정렬 중인 열에 방향 표시
+func (m *watchModel) columnTitle(title string, column sortColumn) string {
+	if m.sortBy != column {
+		return title
+	}
+	if m.asc {
+		return title + "↑"
+	}
+	return title + "↓"
+}
+
+func (m *watchModel) peekLines(width, height int) []string {
+	title := fmt.Sprintf(" %s 메시지 미리보기 (다시 큐에 넣음: redelivered 표시, 순서가 바뀔 수 있음)", m.peekKey)
+	lines := []string{styleBold + fit(title, width) + styleReset}
+	if m.peekWarn != "" {
+		lines = append(lines, styleDLQ+fit(" ⚠️  "+m.peekWarn, width)+styleReset)
+	}
+	if m.peekErr != nil {
+		return append(lines, fit(" 조회 실패: "+m.peekErr.Error(), width))
+	}
+	if len(m.peek) == 0 {
+		return append(lines, " (대기 중인 메시지가 없습니다)")
+	}
+
+	for i, msg := range m.peek {
+		if len(lines)+2 > height {
+			lines = append(lines, fmt.Sprintf(" ... 외 %d건", len(m.peek)-i))
+			break
+		}
+		meta := fmt.Sprintf(" #%d [%s] %s", i+1, msg.RoutingKey, msg.Properties.MessageID)
+		if msg.Redelivered {
+			meta += " (redelivered)"
+		}
+		body, err := msg.Body()
+		text := strings.Join(strings.Fields(string(body)), " ")
+		if err != nil {
+			text = fmt.Sprintf("(본문 디코딩 실패: %v)", err)
+		}
+		if msg.Truncated() {
+			text += fmt.Sprintf(" ... (%d bytes)", msg.PayloadBytes)
+		}
+		lines = append(lines, styleBold+fit(meta, width)+styleReset, fit("   "+text, width))
+	}
+	return lines
+}
+
+// isDeadLetterQueue 이름으로 DLQ/parking-lot 큐 구분
+func isDeadLetterQueue(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, ".dlq") || strings.Contains(name, "dead-letter") || strings.Contains(name, "parking-lot")
+}
+
+func drainText(t monitor.QueueTrend) string {
+	if t.Messages == 0 {
+		return "-"
+	}
+	eta, ok := t.DrainETA()
+	if !ok {
+		return "∞"
+	}
+	return eta.Round(time.Second).String()
+}
+
+// sparkline 값을 최소~최대 범위로 나눠 막대 문자로 표시
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = int((v - lo) / (hi - lo) * float64(len(sparkChars)-1))
+		}
+		b.WriteRune(sparkChars[i])
+	}
+	return b.String()
+}
+
+// truncate 너비를 넘으면 잘라서 …로 표시
+func truncate(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+	return fit(s, width-1) + "…"
+}
+
+// fit 화면 너비에 맞게 자름 (한글 등 넓은 문자는 2칸)
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	w := 0
+	for i, r := range s {
+		rw := runeWidth(r)
+		if w+rw > width {
+			return s[:i]
+		}
+		w += rw
+	}
+	return s
+}
+
+// pad 화면 너비 기준으로 오른쪽을 공백으로 채움 (fmt의 %-*s는 글자 수 기준)
+func pad(s string, width int) string {
+	if w := displayWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+func displayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, // 한글 자모
+		r >= 0x2E80 && r <= 0xA4CF, // CJK
+		r >= 0xAC00 && r <= 0xD7A3, // 한글 음절
+		r >= 0xF900 && r <= 0xFAFF,
+		r >= 0xFF00 && r <= 0xFF60,
+		r >= 0x1F300 && r <= 0x1FAFF: // 이모지
+		return 2
+	}
+	return 1
+}
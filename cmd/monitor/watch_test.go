@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"rabbit-mq-with-go/internal/monitor"
+)
+
+func TestFitAndPad(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		fit   string
+		pad   string
+	}{
+		{"orders", 10, "orders", "orders    "},
+		{"orders", 3, "ord", "orders"},
+		{"주문큐", 4, "주문", "주문큐"},
+		{"주문큐", 5, "주문", "주문큐"},
+		{"주문큐", 8, "주문큐", "주문큐  "},
+		{"abc", 0, "", "abc"},
+	}
+
+	for _, tt := range tests {
+		if got := fit(tt.s, tt.width); got != tt.fit {
+			t.Errorf("fit(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.fit)
+		}
+		if got := pad(tt.s, tt.width); got != tt.pad {
+			t.Errorf("pad(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.pad)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"orders.queue", 20, "orders.queue"},
+		{"orders.queue", 7, "orders…"},
+		{"주문.대기열", 6, "주문.…"},
+	}
+
+	for _, tt := range tests {
+		got := truncate(tt.s, tt.width)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+		if displayWidth(got) > tt.width {
+			t.Errorf("truncate(%q, %d) width %d exceeds %d", tt.s, tt.width, displayWidth(got), tt.width)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   string
+	}{
+		{nil, ""},
+		{[]float64{5, 5, 5}, "▁▁▁"},
+		{[]float64{0, 7}, "▁█"},
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, "▁▂▃▄▅▆▇█"},
+	}
+
+	for _, tt := range tests {
+		if got := sparkline(tt.values); got != tt.want {
+			t.Errorf("sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestDrainText(t *testing.T) {
+	tests := []struct {
+		trend monitor.QueueTrend
+		want  string
+	}{
+		{monitor.QueueTrend{Messages: 0, DrainSeconds: -1}, "-"},
+		{monitor.QueueTrend{Messages: 10, DrainSeconds: -1}, "∞"},
+		{monitor.QueueTrend{Messages: 10, DrainSeconds: 90.4}, "1m30s"},
+	}
+
+	for _, tt := range tests {
+		if got := drainText(tt.trend); got != tt.want {
+			t.Errorf("drainText(%+v) = %q, want %q", tt.trend, got, tt.want)
+		}
+	}
+}
+
+func TestIsDeadLetterQueue(t *testing.T) {
+	tests := map[string]bool{
+		"orders.dlq":              true,
+		"Orders.DLQ":              true,
+		"orders.dead-letter":      true,
+		"orders.parking-lot":      true,
+		"orders.queue":            false,
+		"dlq-replay.audit":        false,
+		"schema.validated.queue":  false,
+		"payments.dead-letter.v2": true,
+	}
+
+	for name, want := range tests {
+		if got := isDeadLetterQueue(name); got != want {
+			t.Errorf("isDeadLetterQueue(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+// testRows 이름, 메시지 수, consumer 수만 다른 테이블 줄
+func testRows() []queueRow {
+	row := func(name string, messages, consumers int) queueRow {
+		return queueRow{
+			key:  monitor.QueueKey{VHost: "/", Name: name},
+			last: monitor.QueueSample{Messages: messages, Consumers: consumers},
+		}
+	}
+	return []queueRow{row("b", 5, 1), row("a", 10, 0), row("c", 1, 3)}
+}
+
+func rowNames(rows []queueRow) string {
+	names := make([]string, len(rows))
+	for i, row := range rows {
+		names[i] = row.key.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSortRows(t *testing.T) {
+	tests := []struct {
+		sortBy sortColumn
+		asc    bool
+		want   string
+	}{
+		{sortName, true, "a,b,c"},
+		{sortName, false, "c,b,a"},
+		{sortMessages, false, "a,b,c"},
+		{sortMessages, true, "c,b,a"},
+		{sortConsumers, false, "c,b,a"},
+	}
+
+	for _, tt := range tests {
+		m := &watchModel{rows: testRows(), sortBy: tt.sortBy, asc: tt.asc}
+		m.sortRows()
+		if got := rowNames(m.rows); got != tt.want {
+			t.Errorf("sortBy=%v asc=%v: %s, want %s", tt.sortBy, tt.asc, got, tt.want)
+		}
+	}
+}
+
+func TestSelectionFollowsQueue(t *testing.T) {
+	m := &watchModel{rows: testRows(), sortBy: sortName, asc: true}
+	m.sortRows()
+
+	if row, _ := m.selectedRow(); row.key.Name != "a" {
+		t.Fatalf("initial selection = %s, want a", row.key.Name)
+	}
+	m.moveSelection(1)
+	m.moveSelection(10) // 끝을 넘어가면 마지막 줄
+	if m.selected.Name != "c" {
+		t.Fatalf("selection = %s, want c", m.selected.Name)
+	}
+
+	// 정렬이 바뀌어도 같은 큐를 선택한 상태로 남는다
+	m.handleKey(context.Background(), "2")
+	if m.selected.Name != "c" || m.selectedIndex() != 2 {
+		t.Errorf("selection after sort = %s (#%d), want c (#2)", m.selected.Name, m.selectedIndex())
+	}
+	m.moveSelection(-10)
+	if m.selected.Name != "a" {
+		t.Errorf("selection = %s, want a", m.selected.Name)
+	}
+}
+
+func TestHandleKeyPurgeConfirm(t *testing.T) {
+	m := &watchModel{rows: testRows()}
+
+	if !m.handleKey(context.Background(), "x") || m.mode != modeConfirmPurge {
+		t.Fatalf("mode after x = %v, want modeConfirmPurge", m.mode)
+	}
+	m.handleKey(context.Background(), "n")
+	if m.mode != modeTable || m.status != "purge 취소" || m.pending {
+		t.Errorf("after n: mode = %v, status = %q, pending = %v", m.mode, m.status, m.pending)
+	}
+
+	if m.handleKey(context.Background(), "q") {
+		t.Error("q should quit")
+	}
+}
+
+// newWatchServer 큐 정보와 메시지를 돌려주는 Management API 서버 (메시지를 가져가면 gets 증가)
+func newWatchServer(t *testing.T, queue string, gets *int) *monitor.RabbitMQMonitor {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/queues/%2F/orders.queue":
+			w.Write([]byte(queue))
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/api/queues/%2F/orders.queue/get":
+			*gets++
+			w.Write([]byte(`[{"routing_key":"orders.queue","payload":"hello","payload_bytes":5,"payload_encoding":"string","properties":[]}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return monitor.NewRabbitMQMonitor(server.URL, "guest", "guest")
+}
+
+// awaitUpdate 백그라운드 작업 결과 하나를 받아 반영
+func awaitUpdate(t *testing.T, m *watchModel) {
+	t.Helper()
+	select {
+	case update := <-m.updates:
+		update(m)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update from the background task")
+	}
+}
+
+func TestPeekSelected(t *testing.T) {
+	tests := []struct {
+		name     string
+		queue    string
+		wantMode watchMode
+		wantGets int
+		wantWarn bool
+	}{
+		{
+			name:     "classic queue",
+			queue:    `{"name":"orders.queue","vhost":"/","arguments":{}}`,
+			wantMode: modePeek,
+			wantGets: 1,
+		},
+		{
+			name:     "quorum queue without delivery limit peeks with a warning",
+			queue:    `{"name":"orders.queue","vhost":"/","arguments":{"x-queue-type":"quorum"}}`,
+			wantMode: modePeek,
+			wantGets: 1,
+			wantWarn: true,
+		},
+		{
+			name:     "quorum queue with delivery limit is not peeked",
+			queue:    `{"name":"orders.queue","vhost":"/","arguments":{"x-queue-type":"quorum","x-delivery-limit":3}}`,
+			wantMode: modeTable,
+			wantGets: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gets int
+			m := &watchModel{
+				mon:     newWatchServer(t, tt.queue, &gets),
+				rows:    []queueRow{{key: monitor.QueueKey{VHost: "/", Name: "orders.queue"}}},
+				updates: make(chan watchUpdate),
+			}
+
+			m.handleKey(context.Background(), "p")
+			if !m.pending || m.mode != modeTable {
+				t.Fatalf("right after starting: pending = %v, mode = %v", m.pending, m.mode)
+			}
+			m.handleKey(context.Background(), "p") // 진행 중에는 다시 시작하지 않는다
+			awaitUpdate(t, m)
+
+			if m.pending {
+				t.Error("still pending after the update")
+			}
+			if m.mode != tt.wantMode || gets != tt.wantGets {
+				t.Errorf("mode = %v, gets = %d, want %v, %d (status %q)", m.mode, gets, tt.wantMode, tt.wantGets, m.status)
+			}
+			if (m.peekWarn != "") != tt.wantWarn {
+				t.Errorf("peekWarn = %q, wantWarn %v", m.peekWarn, tt.wantWarn)
+			}
+			if tt.wantMode == modePeek && (m.peekErr != nil || len(m.peek) != 1) {
+				t.Errorf("peek = %v, %v", m.peek, m.peekErr)
+			}
+		})
+	}
+}
@@ -2,4 +2,11 @@ module rabbit-mq-with-go
 
 go 1.21
 
-require github.com/rabbitmq/amqp091-go v1.10.0
+require (
+	github.com/rabbitmq/amqp091-go v1.10.0
+	go.etcd.io/bbolt v1.3.7
+	golang.org/x/term v0.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.10.0 // indirect
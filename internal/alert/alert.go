@@ -0,0 +1,90 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Severity 알림 심각도
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Alert 알림 하나
+type Alert struct {
+	Key       string            `json:"key"` // 중복 제거 키 (같은 상황은 같은 키)
+	Title     string            `json:"title"`
+	Message   string            `json:"message"`
+	Severity  Severity          `json:"severity"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Count     int               `json:"count,omitempty"`   // 알림에 묶인 이벤트 수
+	Samples   []string          `json:"samples,omitempty"` // 샘플 메시지 ID
+	Timestamp time.Time         `json:"timestamp"`
+	Resolved  bool              `json:"resolved,omitempty"` // 같은 Key의 알림 상황이 해소됨
+}
+
+// Text 사람이 읽는 형식 (메일 본문, 로그 파일용)
+func (a Alert) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s\n", a.Status(), a.Title)
+	fmt.Fprintf(&b, "시각: %s\n", a.Timestamp.Format(time.RFC3339))
+	if a.Message != "" {
+		fmt.Fprintf(&b, "%s\n", a.Message)
+	}
+	keys := make([]string, 0, len(a.Labels))
+	for k := range a.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "  %s: %s\n", k, a.Labels[k])
+	}
+	if a.Count > 0 {
+		fmt.Fprintf(&b, "건수: %d\n", a.Count)
+	}
+	if len(a.Samples) > 0 {
+		fmt.Fprintf(&b, "샘플: %s\n", strings.Join(a.Samples, ", "))
+	}
+	return b.String()
+}
+
+// Status 제목 앞에 붙일 상태 (심각도 또는 RESOLVED)
+func (a Alert) Status() string {
+	if a.Resolved {
+		return "RESOLVED"
+	}
+	return strings.ToUpper(string(a.Severity))
+}
+
+// Sink 알림 전송 대상
+type Sink interface {
+	Send(ctx context.Context, alert Alert) error
+}
+
+// SinkFunc 함수를 Sink로 사용
+type SinkFunc func(ctx context.Context, alert Alert) error
+
+func (f SinkFunc) Send(ctx context.Context, alert Alert) error {
+	return f(ctx, alert)
+}
+
+// Multi 여러 Sink로 모두 전송 (하나가 실패해도 나머지는 전송)
+func Multi(sinks ...Sink) Sink {
+	return SinkFunc(func(ctx context.Context, alert Alert) error {
+		var errs []error
+		for _, sink := range sinks {
+			if err := sink.Send(ctx, alert); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
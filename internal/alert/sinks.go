@@ -0,0 +1,161 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookSink 알림을 JSON으로 POST
+type WebhookSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhookSink 웹훅 Sink 생성 (headers는 인증 토큰 등)
+func NewWebhookSink(url string, headers map[string]string) *WebhookSink {
+	return &WebhookSink{
+		url:     url,
+		headers: headers,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (s *WebhookSink) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("알림 직렬화 실패: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("웹훅 요청 생성 실패: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("웹훅 전송 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("웹훅 응답 오류 (%d): %s", resp.StatusCode, string(msg))
+	}
+	return nil
+}
+
+// SMTPConfig 메일 알림 설정
+type SMTPConfig struct {
+	Addr     string // host:port
+	Username string // 비어 있으면 인증 없이 전송
+	Password string
+	From     string
+	To       []string
+}
+
+// SMTPSink 알림을 메일로 전송
+type SMTPSink struct {
+	config SMTPConfig
+}
+
+// NewSMTPSink 메일 Sink 생성
+func NewSMTPSink(config SMTPConfig) (*SMTPSink, error) {
+	if config.Addr == "" || config.From == "" || len(config.To) == 0 {
+		return nil, errors.New("SMTP 주소, 보내는 사람, 받는 사람이 필요합니다")
+	}
+	return &SMTPSink{config: config}, nil
+}
+
+func (s *SMTPSink) Send(ctx context.Context, alert Alert) error {
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		host := s.config.Addr
+		if i := strings.LastIndex(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, host)
+	}
+
+	msg := s.message(alert)
+
+	// net/smtp는 context를 받지 않으므로 취소 시 결과를 기다리지 않는다
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.config.Addr, auth, s.config.From, s.config.To, msg)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("메일 전송 실패: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("메일 전송 실패: %w", ctx.Err())
+	}
+}
+
+// message 메일 헤더와 본문 작성
+// 제목은 한글이 들어가므로 RFC 2047로 인코딩하고, 줄바꿈으로 헤더가 추가되지 않도록 제목의 CR/LF를 공백으로 바꾼다.
+func (s *SMTPSink) message(alert Alert) []byte {
+	title := strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(alert.Title)
+	subject := fmt.Sprintf("[%s] %s", alert.Status(), title)
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(alert.Text(), "\n", "\r\n"))
+	return msg.Bytes()
+}
+
+// LogFileSink 알림을 로컬 파일에 추가 (로컬 개발용)
+type LogFileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenLogFileSink 로그 파일 열기 (없으면 생성)
+func OpenLogFileSink(path string) (*LogFileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("알림 로그 파일 열기 실패: %w", err)
+	}
+	return &LogFileSink{file: f}, nil
+}
+
+func (s *LogFileSink) Send(ctx context.Context, alert Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.WriteString(alert.Text() + "\n"); err != nil {
+		return fmt.Errorf("알림 로그 쓰기 실패: %w", err)
+	}
+	return nil
+}
+
+// Close 파일 닫기
+func (s *LogFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,208 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testAlert() Alert {
+	return Alert{
+		Key:       "dlq:orders",
+		Title:     "orders.dlq 메시지 급증",
+		Message:   "5분 동안 12개",
+		Severity:  SeverityWarning,
+		Labels:    map[string]string{"queue": "orders.dlq"},
+		Count:     12,
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+// mailHeaders 메일 메시지의 헤더 부분을 이름 → 값으로 분리
+func mailHeaders(t *testing.T, msg []byte) (map[string]string, string) {
+	t.Helper()
+	head, body, ok := strings.Cut(string(msg), "\r\n\r\n")
+	if !ok {
+		t.Fatalf("no blank line between headers and body: %q", msg)
+	}
+	headers := make(map[string]string)
+	for _, line := range strings.Split(head, "\r\n") {
+		name, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			t.Fatalf("malformed header line: %q", line)
+		}
+		if _, dup := headers[name]; dup {
+			t.Fatalf("duplicate header: %s", name)
+		}
+		headers[name] = value
+	}
+	return headers, body
+}
+
+func TestSMTPSinkMessage(t *testing.T) {
+	sink, err := NewSMTPSink(SMTPConfig{
+		Addr: "localhost:25",
+		From: "alerts@example.com",
+		To:   []string{"ops@example.com", "dev@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("NewSMTPSink() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		title       string
+		wantSubject string
+	}{
+		{"korean title", "orders.dlq 메시지 급증", "[WARNING] orders.dlq 메시지 급증"},
+		{"ascii title", "orders.dlq spike", "[WARNING] orders.dlq spike"},
+		{"header injection", "spike\r\nBcc: attacker@example.com", "[WARNING] spike Bcc: attacker@example.com"},
+		{"bare LF", "spike\nX-Injected: 1", "[WARNING] spike X-Injected: 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := testAlert()
+			a.Title = tt.title
+			headers, body := mailHeaders(t, sink.message(a))
+
+			for _, name := range []string{"Bcc", "X-Injected"} {
+				if _, ok := headers[name]; ok {
+					t.Errorf("line break in title injected a %s header", name)
+				}
+			}
+
+			subject, err := new(mime.WordDecoder).DecodeHeader(headers["Subject"])
+			if err != nil {
+				t.Fatalf("decode Subject: %v", err)
+			}
+			if subject != tt.wantSubject {
+				t.Errorf("Subject = %q, want %q", subject, tt.wantSubject)
+			}
+
+			want := map[string]string{
+				"From":         "alerts@example.com",
+				"To":           "ops@example.com, dev@example.com",
+				"MIME-Version": "1.0",
+				"Content-Type": "text/plain; charset=UTF-8",
+			}
+			for name, value := range want {
+				if headers[name] != value {
+					t.Errorf("%s = %q, want %q", name, headers[name], value)
+				}
+			}
+
+			if strings.Contains(strings.ReplaceAll(body, "\r\n", ""), "\n") {
+				t.Errorf("body line breaks are not CRLF: %q", body)
+			}
+		})
+	}
+}
+
+func TestNewSMTPSinkRequiresAddresses(t *testing.T) {
+	configs := []SMTPConfig{
+		{From: "a@example.com", To: []string{"b@example.com"}},
+		{Addr: "localhost:25", To: []string{"b@example.com"}},
+		{Addr: "localhost:25", From: "a@example.com"},
+	}
+	for _, config := range configs {
+		if _, err := NewSMTPSink(config); err == nil {
+			t.Errorf("NewSMTPSink(%+v) error = nil, want error", config)
+		}
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got Alert
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, map[string]string{"Authorization": "Bearer token"})
+	if err := sink.Send(context.Background(), testAlert()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got.Key != "dlq:orders" || got.Count != 12 {
+		t.Errorf("received alert = %+v", got)
+	}
+	if auth != "Bearer token" {
+		t.Errorf("Authorization = %q, want %q", auth, "Bearer token")
+	}
+}
+
+func TestWebhookSinkErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewWebhookSink(server.URL, nil).Send(context.Background(), testAlert())
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Send() error = %v, want 503 status error", err)
+	}
+}
+
+func TestLogFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.log")
+	sink, err := OpenLogFileSink(path)
+	if err != nil {
+		t.Fatalf("OpenLogFileSink() error = %v", err)
+	}
+
+	resolved := testAlert()
+	resolved.Resolved = true
+	for _, a := range []Alert{testAlert(), resolved} {
+		if err := sink.Send(context.Background(), a); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"[WARNING] orders.dlq 메시지 급증", "[RESOLVED] orders.dlq 메시지 급증", "queue: orders.dlq"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("log file missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestMultiSendsToAllSinks(t *testing.T) {
+	var sent []string
+	record := func(name string, err error) Sink {
+		return SinkFunc(func(ctx context.Context, alert Alert) error {
+			sent = append(sent, name)
+			return err
+		})
+	}
+	errDown := errors.New("down")
+
+	err := Multi(record("first", errDown), record("second", nil)).Send(context.Background(), testAlert())
+	if !errors.Is(err, errDown) {
+		t.Errorf("Send() error = %v, want %v", err, errDown)
+	}
+	if strings.Join(sent, ",") != "first,second" {
+		t.Errorf("sent to %v, want every sink even after a failure", sent)
+	}
+}
@@ -0,0 +1,423 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeAction 쓰기 작업 결과
+type ChangeAction string
+
+const (
+	ChangeCreated   ChangeAction = "created"
+	ChangeUpdated   ChangeAction = "updated"
+	ChangeDeleted   ChangeAction = "deleted"
+	ChangePurged    ChangeAction = "purged"
+	ChangeClosed    ChangeAction = "closed"
+	ChangeUnchanged ChangeAction = "unchanged" // 이미 원하는 상태 (요청하지 않음)
+)
+
+// Change 쓰기 작업이 실제로 바꾼 내용
+type Change struct {
+	Action ChangeAction `json:"action"`
+	Kind   string       `json:"kind"` // queue, exchange, binding, policy, connection
+	VHost  string       `json:"vhost"`
+	Name   string       `json:"name"`
+	Detail string       `json:"detail,omitempty"`
+}
+
+// Changed 실제로 변경이 있었는지
+func (c Change) Changed() bool {
+	return c.Action != ChangeUnchanged
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s %s %s (vhost: %s)", c.Kind, c.Name, c.Action, c.VHost)
+	if c.Detail != "" {
+		s += ": " + c.Detail
+	}
+	return s
+}
+
+// QueueSpec 큐 선언 설정
+type QueueSpec struct {
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+// ExchangeSpec Exchange 선언 설정
+type ExchangeSpec struct {
+	Type       string                 `json:"type"` // direct, fanout, topic, headers
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Internal   bool                   `json:"internal"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+// BindingSpec 바인딩 설정
+type BindingSpec struct {
+	Source          string // 원본 exchange
+	Destination     string // 대상 큐 또는 exchange
+	DestinationType string // queue (기본) 또는 exchange
+	RoutingKey      string
+	Arguments       map[string]interface{} // headers exchange 매칭 조건 등
+}
+
+// Policy 정책 설정
+type Policy struct {
+	Pattern    string                 `json:"pattern"` // 적용할 큐/exchange 이름 정규식
+	Definition map[string]interface{} `json:"definition"`
+	Priority   int                    `json:"priority"`
+	ApplyTo    string                 `json:"apply-to"` // queues, exchanges, all (기본 all)
+}
+
+// PolicyInfo 등록된 정책
+type PolicyInfo struct {
+	VHost string `json:"vhost"`
+	Name  string `json:"name"`
+	Policy
+}
+
+// DeclareQueue 큐 선언 (같은 설정으로 이미 있으면 변경 없음)
+// 큐 설정은 선언 후 바꿀 수 없으므로 다른 설정으로 이미 있으면 에러를 반환한다.
+func (m *RabbitMQMonitor) DeclareQueue(ctx context.Context, vhost, name string, spec QueueSpec) (Change, error) {
+	change := Change{Kind: "queue", VHost: vhost, Name: name}
+
+	existing, err := m.GetQueue(ctx, vhost, name)
+	switch {
+	case err == nil:
+		current := QueueSpec{Durable: existing.Durable, AutoDelete: existing.AutoDelete, Arguments: existing.Arguments}
+		// 최신 RabbitMQ는 기본 큐 타입도 arguments에 표시한다
+		if _, ok := spec.Arguments["x-queue-type"]; !ok && current.Arguments["x-queue-type"] == "classic" {
+			delete(current.Arguments, "x-queue-type")
+		}
+		if diff := specDiff(current, spec); diff != "" {
+			return change, fmt.Errorf("큐 %s가 다른 설정으로 이미 존재합니다 (%s), 삭제 후 다시 선언해야 합니다", name, diff)
+		}
+		change.Action = ChangeUnchanged
+		return change, nil
+	case !errors.Is(err, ErrNotFound):
+		return change, err
+	}
+
+	if spec.Arguments == nil {
+		spec.Arguments = map[string]interface{}{}
+	}
+	if _, err := m.do(ctx, http.MethodPut, apiPath("queues", vhost, name), spec); err != nil {
+		return change, fmt.Errorf("큐 선언 실패: %w", err)
+	}
+	change.Action = ChangeCreated
+	return change, nil
+}
+
+// DeleteQueueOptions 큐 삭제 조건
+type DeleteQueueOptions struct {
+	IfEmpty  bool // 메시지가 없을 때만 삭제
+	IfUnused bool // consumer가 없을 때만 삭제
+}
+
+// DeleteQueue 큐 삭제 (없으면 변경 없음)
+func (m *RabbitMQMonitor) DeleteQueue(ctx context.Context, vhost, name string, opts DeleteQueueOptions) (Change, error) {
+	change := Change{Kind: "queue", VHost: vhost, Name: name}
+
+	existing, err := m.GetQueue(ctx, vhost, name)
+	if errors.Is(err, ErrNotFound) {
+		change.Action = ChangeUnchanged
+		change.Detail = "큐가 없음"
+		return change, nil
+	}
+	if err != nil {
+		return change, err
+	}
+
+	query := url.Values{}
+	if opts.IfEmpty {
+		query.Set("if-empty", "true")
+	}
+	if opts.IfUnused {
+		query.Set("if-unused", "true")
+	}
+	endpoint := apiPath("queues", vhost, name)
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	if _, err := m.do(ctx, http.MethodDelete, endpoint, nil); err != nil {
+		return change, fmt.Errorf("큐 삭제 실패: %w", err)
+	}
+	change.Action = ChangeDeleted
+	change.Detail = fmt.Sprintf("메시지 %d건, consumer %d개", existing.Messages, existing.Consumers)
+	return change, nil
+}
+
+// PurgeQueue 큐의 대기 메시지 모두 삭제 (unacked 메시지는 남음)
+func (m *RabbitMQMonitor) PurgeQueue(ctx context.Context, vhost, name string) (Change, error) {
+	change := Change{Kind: "queue", VHost: vhost, Name: name}
+
+	existing, err := m.GetQueue(ctx, vhost, name)
+	if err != nil {
+		return change, err
+	}
+	if existing.MessagesReady == 0 {
+		change.Action = ChangeUnchanged
+		change.Detail = "대기 메시지 없음"
+		return change, nil
+	}
+
+	if _, err := m.do(ctx, http.MethodDelete, apiPath("queues", vhost, name, "contents"), nil); err != nil {
+		return change, fmt.Errorf("큐 비우기 실패: %w", err)
+	}
+	change.Action = ChangePurged
+	// 통계는 몇 초 간격으로 갱신되므로 직전 조회 값 기준
+	change.Detail = fmt.Sprintf("약 %d건 삭제", existing.MessagesReady)
+	return change, nil
+}
+
+// DeclareExchange Exchange 선언 (같은 설정으로 이미 있으면 변경 없음)
+func (m *RabbitMQMonitor) DeclareExchange(ctx context.Context, vhost, name string, spec ExchangeSpec) (Change, error) {
+	change := Change{Kind: "exchange", VHost: vhost, Name: name}
+	if spec.Type == "" {
+		return change, errors.New("exchange 타입이 필요합니다")
+	}
+
+	var existing ExchangeInfo
+	err := m.getJSON(ctx, apiPath("exchanges", vhost, name), &existing)
+	switch {
+	case err == nil:
+		current := ExchangeSpec{
+			Type:       existing.Type,
+			Durable:    existing.Durable,
+			AutoDelete: existing.AutoDelete,
+			Internal:   existing.Internal,
+			Arguments:  existing.Arguments,
+		}
+		if diff := specDiff(current, spec); diff != "" {
+			return change, fmt.Errorf("exchange %s가 다른 설정으로 이미 존재합니다 (%s), 삭제 후 다시 선언해야 합니다", name, diff)
+		}
+		change.Action = ChangeUnchanged
+		return change, nil
+	case !errors.Is(err, ErrNotFound):
+		return change, err
+	}
+
+	if spec.Arguments == nil {
+		spec.Arguments = map[string]interface{}{}
+	}
+	if _, err := m.do(ctx, http.MethodPut, apiPath("exchanges", vhost, name), spec); err != nil {
+		return change, fmt.Errorf("exchange 선언 실패: %w", err)
+	}
+	change.Action = ChangeCreated
+	return change, nil
+}
+
+// DeleteExchange Exchange 삭제 (ifUnused면 바인딩이 없을 때만)
+func (m *RabbitMQMonitor) DeleteExchange(ctx context.Context, vhost, name string, ifUnused bool) (Change, error) {
+	change := Change{Kind: "exchange", VHost: vhost, Name: name}
+
+	endpoint := apiPath("exchanges", vhost, name)
+	if ifUnused {
+		endpoint += "?if-unused=true"
+	}
+	_, err := m.do(ctx, http.MethodDelete, endpoint, nil)
+	if errors.Is(err, ErrNotFound) {
+		change.Action = ChangeUnchanged
+		change.Detail = "exchange가 없음"
+		return change, nil
+	}
+	if err != nil {
+		return change, fmt.Errorf("exchange 삭제 실패: %w", err)
+	}
+	change.Action = ChangeDeleted
+	return change, nil
+}
+
+// CreateBinding 바인딩 생성 (같은 바인딩이 이미 있으면 변경 없음)
+func (m *RabbitMQMonitor) CreateBinding(ctx context.Context, vhost string, spec BindingSpec) (Change, error) {
+	change := Change{Kind: "binding", VHost: vhost, Name: spec.name()}
+
+	existing, err := m.findBinding(ctx, vhost, spec)
+	if err != nil {
+		return change, err
+	}
+	if existing != nil {
+		change.Action = ChangeUnchanged
+		return change, nil
+	}
+
+	body := map[string]interface{}{
+		"routing_key": spec.RoutingKey,
+		"arguments":   spec.Arguments,
+	}
+	if spec.Arguments == nil {
+		body["arguments"] = map[string]interface{}{}
+	}
+	if _, err := m.post(ctx, spec.path(vhost), body); err != nil {
+		return change, fmt.Errorf("바인딩 생성 실패: %w", err)
+	}
+	change.Action = ChangeCreated
+	return change, nil
+}
+
+// DeleteBinding 바인딩 삭제 (없으면 변경 없음)
+func (m *RabbitMQMonitor) DeleteBinding(ctx context.Context, vhost string, spec BindingSpec) (Change, error) {
+	change := Change{Kind: "binding", VHost: vhost, Name: spec.name()}
+
+	existing, err := m.findBinding(ctx, vhost, spec)
+	if err != nil {
+		return change, err
+	}
+	if existing == nil {
+		change.Action = ChangeUnchanged
+		change.Detail = "바인딩이 없음"
+		return change, nil
+	}
+
+	// properties_key는 관리 UI와 같이 그대로 한 번 더 이스케이프해 경로에 넣는다
+	endpoint := spec.path(vhost) + "/" + url.PathEscape(existing.PropertiesKey)
+	if _, err := m.do(ctx, http.MethodDelete, endpoint, nil); err != nil {
+		return change, fmt.Errorf("바인딩 삭제 실패: %w", err)
+	}
+	change.Action = ChangeDeleted
+	return change, nil
+}
+
+// findBinding routing key와 arguments가 같은 바인딩 (없으면 nil)
+func (m *RabbitMQMonitor) findBinding(ctx context.Context, vhost string, spec BindingSpec) (*BindingInfo, error) {
+	var bindings []BindingInfo
+	err := m.getJSON(ctx, spec.path(vhost), &bindings)
+	if err != nil {
+		return nil, err
+	}
+	for i, b := range bindings {
+		if b.RoutingKey == spec.RoutingKey && sameArgs(b.Arguments, spec.Arguments) {
+			return &bindings[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// path /api/bindings/{vhost}/e/{source}/{q|e}/{destination}
+func (b BindingSpec) path(vhost string) string {
+	dest := "q"
+	if b.DestinationType == "exchange" {
+		dest = "e"
+	}
+	return apiPath("bindings", vhost, "e", b.Source, dest, b.Destination)
+}
+
+func (b BindingSpec) name() string {
+	return fmt.Sprintf("%s → %s (%s)", b.Source, b.Destination, b.RoutingKey)
+}
+
+// ListPolicies vhost의 정책 목록 조회
+func (m *RabbitMQMonitor) ListPolicies(ctx context.Context, vhost string) ([]PolicyInfo, error) {
+	var policies []PolicyInfo
+	if err := m.getJSON(ctx, apiPath("policies", vhost), &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// SetPolicy 정책 생성 또는 변경 (같은 정책이면 변경 없음)
+func (m *RabbitMQMonitor) SetPolicy(ctx context.Context, vhost, name string, policy Policy) (Change, error) {
+	change := Change{Kind: "policy", VHost: vhost, Name: name}
+	if policy.Pattern == "" || len(policy.Definition) == 0 {
+		return change, errors.New("정책 pattern과 definition이 필요합니다")
+	}
+	if policy.ApplyTo == "" {
+		policy.ApplyTo = "all"
+	}
+
+	var existing PolicyInfo
+	err := m.getJSON(ctx, apiPath("policies", vhost, name), &existing)
+	switch {
+	case err == nil:
+		diff := specDiff(existing.Policy, policy)
+		if diff == "" {
+			change.Action = ChangeUnchanged
+			return change, nil
+		}
+		change.Action = ChangeUpdated
+		change.Detail = diff
+	case errors.Is(err, ErrNotFound):
+		change.Action = ChangeCreated
+	default:
+		return change, err
+	}
+
+	if _, err := m.do(ctx, http.MethodPut, apiPath("policies", vhost, name), policy); err != nil {
+		return change, fmt.Errorf("정책 설정 실패: %w", err)
+	}
+	return change, nil
+}
+
+// DeletePolicy 정책 삭제 (없으면 변경 없음)
+func (m *RabbitMQMonitor) DeletePolicy(ctx context.Context, vhost, name string) (Change, error) {
+	change := Change{Kind: "policy", VHost: vhost, Name: name}
+
+	_, err := m.do(ctx, http.MethodDelete, apiPath("policies", vhost, name), nil)
+	if errors.Is(err, ErrNotFound) {
+		change.Action = ChangeUnchanged
+		change.Detail = "정책이 없음"
+		return change, nil
+	}
+	if err != nil {
+		return change, fmt.Errorf("정책 삭제 실패: %w", err)
+	}
+	change.Action = ChangeDeleted
+	return change, nil
+}
+
+// specDiff 두 설정의 JSON 필드별 차이 (같으면 빈 문자열)
+// API 응답과 요청 값의 숫자 타입 등이 달라도 JSON 기준으로 비교한다.
+func specDiff(current, desired interface{}) string {
+	a, b := jsonFields(current), jsonFields(desired)
+
+	keys := make(map[string]bool)
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var diffs []string
+	for _, k := range sorted {
+		if !reflect.DeepEqual(emptyToNil(a[k]), emptyToNil(b[k])) {
+			diffs = append(diffs, fmt.Sprintf("%s: %v → %v", k, a[k], b[k]))
+		}
+	}
+	return strings.Join(diffs, ", ")
+}
+
+// sameArgs 바인딩 arguments 비교 (nil과 빈 map은 같음)
+func sameArgs(a, b map[string]interface{}) bool {
+	return specDiff(a, b) == ""
+}
+
+func jsonFields(v interface{}) map[string]interface{} {
+	data, _ := json.Marshal(v)
+	fields := map[string]interface{}{}
+	json.Unmarshal(data, &fields)
+	return fields
+}
+
+func emptyToNil(v interface{}) interface{} {
+	if m, ok := v.(map[string]interface{}); ok && len(m) == 0 {
+		return nil
+	}
+	return v
+}
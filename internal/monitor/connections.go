@@ -0,0 +1,193 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ConnectionInfo 클라이언트 연결 정보
+type ConnectionInfo struct {
+	Name             string                 `json:"name"`
+	VHost            string                 `json:"vhost"`
+	User             string                 `json:"user"`
+	State            string                 `json:"state"`
+	Protocol         string                 `json:"protocol"`
+	PeerHost         string                 `json:"peer_host"` // 클라이언트 주소
+	PeerPort         int                    `json:"peer_port"`
+	Node             string                 `json:"node"`
+	Channels         int                    `json:"channels"`
+	ConnectedAt      int64                  `json:"connected_at"` // Unix 밀리초
+	ClientProperties map[string]interface{} `json:"client_properties"`
+}
+
+// ClientName 클라이언트가 지정한 connection_name (없으면 빈 문자열)
+func (c ConnectionInfo) ClientName() string {
+	name, _ := c.ClientProperties["connection_name"].(string)
+	return name
+}
+
+// Product 클라이언트 라이브러리 이름과 버전
+func (c ConnectionInfo) Product() string {
+	product, _ := c.ClientProperties["product"].(string)
+	version, _ := c.ClientProperties["version"].(string)
+	if version != "" {
+		return product + " " + version
+	}
+	return product
+}
+
+// Connected 연결된 시각
+func (c ConnectionInfo) Connected() time.Time {
+	return time.UnixMilli(c.ConnectedAt)
+}
+
+// ConnectionDetails 채널이 속한 연결 요약
+type ConnectionDetails struct {
+	Name     string `json:"name"`
+	PeerHost string `json:"peer_host"`
+	PeerPort int    `json:"peer_port"`
+}
+
+// ChannelInfo 채널 정보
+type ChannelInfo struct {
+	Name                string            `json:"name"`
+	Number              int               `json:"number"`
+	VHost               string            `json:"vhost"`
+	User                string            `json:"user"`
+	State               string            `json:"state"`
+	PrefetchCount       int               `json:"prefetch_count"`
+	GlobalPrefetchCount int               `json:"global_prefetch_count"`
+	MessagesUnacked     int               `json:"messages_unacknowledged"`
+	MessagesUnconfirmed int               `json:"messages_unconfirmed"`
+	ConsumerCount       int               `json:"consumer_count"`
+	Confirm             bool              `json:"confirm"`
+	Connection          ConnectionDetails `json:"connection_details"`
+}
+
+// ConsumerInfo consumer 정보
+type ConsumerInfo struct {
+	ConsumerTag   string `json:"consumer_tag"`
+	AckRequired   bool   `json:"ack_required"`
+	Exclusive     bool   `json:"exclusive"`
+	Active        bool   `json:"active"` // single active consumer에서 대기 중이면 false
+	PrefetchCount int    `json:"prefetch_count"`
+	Queue         struct {
+		Name  string `json:"name"`
+		VHost string `json:"vhost"`
+	} `json:"queue"`
+	Channel struct {
+		Name           string `json:"name"`
+		Number         int    `json:"number"`
+		ConnectionName string `json:"connection_name"`
+		PeerHost       string `json:"peer_host"`
+		PeerPort       int    `json:"peer_port"`
+		User           string `json:"user"`
+	} `json:"channel_details"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// ListConnections 모든 연결 목록 조회
+func (m *RabbitMQMonitor) ListConnections(ctx context.Context) ([]ConnectionInfo, error) {
+	var connections []ConnectionInfo
+	if err := m.getJSON(ctx, apiPath("connections"), &connections); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+// GetConnection 특정 연결 조회 (없으면 ErrNotFound)
+func (m *RabbitMQMonitor) GetConnection(ctx context.Context, name string) (*ConnectionInfo, error) {
+	var connection ConnectionInfo
+	if err := m.getJSON(ctx, apiPath("connections", name), &connection); err != nil {
+		return nil, err
+	}
+	return &connection, nil
+}
+
+// ListChannels 모든 채널 목록 조회
+func (m *RabbitMQMonitor) ListChannels(ctx context.Context) ([]ChannelInfo, error) {
+	var channels []ChannelInfo
+	if err := m.getJSON(ctx, apiPath("channels"), &channels); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// ListConsumers 모든 consumer 목록 조회
+func (m *RabbitMQMonitor) ListConsumers(ctx context.Context) ([]ConsumerInfo, error) {
+	var consumers []ConsumerInfo
+	if err := m.getJSON(ctx, apiPath("consumers"), &consumers); err != nil {
+		return nil, err
+	}
+	return consumers, nil
+}
+
+// ConnectionUnacked 연결별 unacked 메시지 합계
+type ConnectionUnacked struct {
+	Connection ConnectionDetails
+	Channels   int
+	Unacked    int
+}
+
+// UnackedByConnection 채널의 unacked 수를 연결별로 합산 (많은 순)
+// 어느 클라이언트가 메시지를 붙잡고 있는지 찾을 때 사용한다.
+func (m *RabbitMQMonitor) UnackedByConnection(ctx context.Context) ([]ConnectionUnacked, error) {
+	channels, err := m.ListChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*ConnectionUnacked)
+	var result []*ConnectionUnacked
+	for _, ch := range channels {
+		entry, ok := byName[ch.Connection.Name]
+		if !ok {
+			entry = &ConnectionUnacked{Connection: ch.Connection}
+			byName[ch.Connection.Name] = entry
+			result = append(result, entry)
+		}
+		entry.Channels++
+		entry.Unacked += ch.MessagesUnacked
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Unacked > result[j].Unacked
+	})
+	sorted := make([]ConnectionUnacked, len(result))
+	for i, entry := range result {
+		sorted[i] = *entry
+	}
+	return sorted, nil
+}
+
+// CloseConnection 연결 강제 종료 (reason은 클라이언트에 전달됨, 연결이 없으면 변경 없음)
+// 종료된 연결의 unacked 메시지는 큐로 돌아가 다른 consumer에게 전달된다.
+func (m *RabbitMQMonitor) CloseConnection(ctx context.Context, name, reason string) (Change, error) {
+	change := Change{Kind: "connection", Name: name}
+
+	connection, err := m.GetConnection(ctx, name)
+	if errors.Is(err, ErrNotFound) {
+		change.Action = ChangeUnchanged
+		change.Detail = "연결이 없음"
+		return change, nil
+	}
+	if err != nil {
+		return change, err
+	}
+	change.VHost = connection.VHost
+
+	header := http.Header{}
+	if reason != "" {
+		header.Set("X-Reason", reason)
+	}
+	if _, err := m.send(ctx, http.MethodDelete, apiPath("connections", name), nil, header); err != nil {
+		return change, fmt.Errorf("연결 종료 실패: %w", err)
+	}
+	change.Action = ChangeClosed
+	change.Detail = fmt.Sprintf("%s (%s), 채널 %d개", connection.PeerHost, connection.ClientName(), connection.Channels)
+	return change, nil
+}
@@ -0,0 +1,64 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// errors.Is로 구분할 수 있는 Management API 에러
+var (
+	ErrUnauthorized = errors.New("인증 실패")        // 401 (계정 또는 권한 오류)
+	ErrNotFound     = errors.New("리소스를 찾을 수 없음") // 404 (vhost, 큐 등이 없음)
+	ErrConnection   = errors.New("연결 실패")        // 서버에 연결할 수 없음
+)
+
+// APIError Management API가 성공이 아닌 상태 코드로 응답
+type APIError struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Reason     string // 응답 본문의 reason 필드 (있으면)
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API 요청 실패: %s %s: %d %s", e.Method, e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Reason != "" && e.Reason != http.StatusText(e.StatusCode) {
+		msg += " (" + e.Reason + ")"
+	}
+	return msg
+}
+
+// Is 상태 코드를 ErrUnauthorized, ErrNotFound와 비교
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// retryable 다시 시도하면 성공할 수 있는 상태 코드
+func (e *APIError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// ConnectionError 서버에 연결하지 못함 (errors.Is(err, ErrConnection))
+type ConnectionError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("Management API 연결 실패 (%s): %v", e.Endpoint, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ConnectionError) Is(target error) bool {
+	return target == ErrConnection
+}
@@ -0,0 +1,279 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 기본 수집 주기
+const defaultExportInterval = 15 * time.Second
+
+// ExporterConfig Prometheus exporter 설정
+type ExporterConfig struct {
+	Interval  time.Duration // Management API 수집 주기 (기본 15s)
+	Namespace string        // 메트릭 이름 접두사 (기본 rabbitmq)
+	Queues    QueueSource   // 큐 목록 조회 (nil이면 Management API, Sampler를 넘기면 수집 결과 공유)
+}
+
+// Exporter Management API를 주기적으로 수집해 /metrics를 Prometheus text 형식으로 제공
+// 스크랩마다 API를 호출하지 않고 마지막 수집 결과를 그대로 응답한다.
+type Exporter struct {
+	monitor *RabbitMQMonitor
+	config  ExporterConfig
+
+	mu      sync.RWMutex
+	metrics []byte
+}
+
+// NewExporter Exporter 생성
+func NewExporter(monitor *RabbitMQMonitor, config ExporterConfig) *Exporter {
+	if config.Interval <= 0 {
+		config.Interval = defaultExportInterval
+	}
+	if config.Namespace == "" {
+		config.Namespace = "rabbitmq"
+	}
+	if config.Queues == nil {
+		config.Queues = monitor
+	}
+	return &Exporter{monitor: monitor, config: config}
+}
+
+// Run ctx가 끝날 때까지 주기적으로 수집
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		e.Collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect 한 번 수집해 응답할 메트릭 갱신
+// 수집에 실패하면 오래된 값을 내보내지 않고 up 0만 남긴다.
+func (e *Exporter) Collect(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, e.config.Interval)
+	defer cancel()
+
+	start := time.Now()
+	w := newMetricWriter(e.config.Namespace)
+	err := e.collect(ctx, w)
+	if err != nil {
+		log.Printf("[❌] 메트릭 수집 실패: %v", err)
+		w = newMetricWriter(e.config.Namespace)
+		w.gauge("up", "Management API 수집 성공 여부", 0)
+	} else {
+		w.gauge("up", "Management API 수집 성공 여부", 1)
+	}
+	w.gauge("scrape_duration_seconds", "Management API 수집 소요 시간", time.Since(start).Seconds())
+
+	e.mu.Lock()
+	e.metrics = w.bytes()
+	e.mu.Unlock()
+}
+
+func (e *Exporter) collect(ctx context.Context, w *metricWriter) error {
+	overview, err := e.monitor.GetOverview(ctx)
+	if err != nil {
+		return fmt.Errorf("overview 조회 실패: %w", err)
+	}
+	queues, err := e.config.Queues.ListQueues(ctx)
+	if err != nil {
+		return fmt.Errorf("큐 목록 조회 실패: %w", err)
+	}
+	exchanges, err := e.monitor.ListExchanges(ctx)
+	if err != nil {
+		return fmt.Errorf("exchange 목록 조회 실패: %w", err)
+	}
+	nodes, err := e.monitor.ListNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("노드 목록 조회 실패: %w", err)
+	}
+
+	cluster := label{"cluster", overview.ClusterName}
+	w.gauge("messages", "전체 큐 메시지 수", float64(overview.QueueTotals.Messages), cluster)
+	w.gauge("messages_ready", "전체 큐 대기 메시지 수", float64(overview.QueueTotals.MessagesReady), cluster)
+	w.gauge("messages_unacked", "전체 큐 처리 중(unacked) 메시지 수", float64(overview.QueueTotals.MessagesUnacked), cluster)
+	w.gauge("connections", "연결 수", float64(overview.ObjectTotals.Connections), cluster)
+	w.gauge("channels", "채널 수", float64(overview.ObjectTotals.Channels), cluster)
+	w.gauge("exchanges", "Exchange 수", float64(overview.ObjectTotals.Exchanges), cluster)
+	w.gauge("queues", "큐 수", float64(overview.ObjectTotals.Queues), cluster)
+	w.gauge("consumers", "consumer 수", float64(overview.ObjectTotals.Consumers), cluster)
+
+	for _, q := range queues {
+		labels := []label{{"vhost", q.VHost}, {"queue", q.Name}}
+		w.gauge("queue_messages", "큐 메시지 수", float64(q.Messages), labels...)
+		w.gauge("queue_messages_ready", "큐 대기 메시지 수", float64(q.MessagesReady), labels...)
+		w.gauge("queue_messages_unacked", "큐 처리 중(unacked) 메시지 수", float64(q.MessagesUnacked), labels...)
+		w.gauge("queue_consumers", "큐 consumer 수", float64(q.Consumers), labels...)
+
+		stats := q.MessageStats
+		if stats == nil {
+			stats = &MessageStats{}
+		}
+		w.counter("queue_messages_published_total", "큐에 publish된 메시지 누적 수", float64(stats.Publish), labels...)
+		w.counter("queue_messages_delivered_total", "큐에서 전달된 메시지 누적 수", float64(stats.Deliver), labels...)
+		w.counter("queue_messages_acked_total", "큐에서 ack된 메시지 누적 수", float64(stats.Ack), labels...)
+		w.gauge("queue_publish_rate", "큐 publish 속도 (초당)", stats.PublishDetails.Rate, labels...)
+		w.gauge("queue_deliver_rate", "큐 전달 속도 (초당)", stats.DeliverDetails.Rate, labels...)
+		w.gauge("queue_ack_rate", "큐 ack 속도 (초당)", stats.AckDetails.Rate, labels...)
+	}
+
+	for _, ex := range exchanges {
+		if ex.Name == "" {
+			continue // 기본 exchange는 이름이 없어 라벨로 구분할 수 없음
+		}
+		labels := []label{{"vhost", ex.VHost}, {"exchange", ex.Name}, {"type", ex.Type}}
+		stats := ex.MessageStats
+		if stats == nil {
+			stats = &ExchangeMessageStats{}
+		}
+		w.counter("exchange_messages_published_in_total", "Exchange로 publish된 메시지 누적 수", float64(stats.PublishIn), labels...)
+		w.counter("exchange_messages_published_out_total", "Exchange에서 라우팅된 메시지 누적 수", float64(stats.PublishOut), labels...)
+		w.gauge("exchange_publish_in_rate", "Exchange publish 속도 (초당)", stats.PublishInDetails.Rate, labels...)
+		w.gauge("exchange_publish_out_rate", "Exchange 라우팅 속도 (초당)", stats.PublishOutDetails.Rate, labels...)
+	}
+
+	for _, n := range nodes {
+		node := label{"node", n.Name}
+		w.gauge("node_running", "노드 실행 여부", boolValue(n.Running), node)
+		w.gauge("node_mem_used_bytes", "노드 메모리 사용량", float64(n.MemUsed), node)
+		w.gauge("node_mem_limit_bytes", "노드 메모리 watermark", float64(n.MemLimit), node)
+		w.gauge("node_mem_alarm", "노드 메모리 알람 여부", boolValue(n.MemAlarm), node)
+		w.gauge("node_disk_free_bytes", "노드 디스크 여유 공간", float64(n.DiskFree), node)
+		w.gauge("node_disk_free_limit_bytes", "노드 디스크 watermark", float64(n.DiskFreeLimit), node)
+		w.gauge("node_disk_free_alarm", "노드 디스크 알람 여부", boolValue(n.DiskFreeAlarm), node)
+		w.gauge("node_fd_used", "노드 파일 디스크립터 사용 수", float64(n.FDUsed), node)
+		w.gauge("node_fd_total", "노드 파일 디스크립터 한도", float64(n.FDTotal), node)
+	}
+	return nil
+}
+
+// ServeHTTP 마지막 수집 결과 응답 (아직 수집 전이면 503)
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.RLock()
+	metrics := e.metrics
+	e.mu.RUnlock()
+
+	if metrics == nil {
+		http.Error(w, "아직 수집된 메트릭이 없습니다", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(metrics)
+}
+
+type label struct {
+	name, value string
+}
+
+type sample struct {
+	labels []label
+	value  float64
+}
+
+// metricFamily 같은 이름의 메트릭 묶음 (HELP, TYPE은 한 번만 출력)
+type metricFamily struct {
+	name, help, kind string
+	samples          []sample
+}
+
+// metricWriter Prometheus text 형식 작성기
+type metricWriter struct {
+	namespace string
+	families  map[string]*metricFamily
+	order     []string
+}
+
+func newMetricWriter(namespace string) *metricWriter {
+	return &metricWriter{namespace: namespace, families: make(map[string]*metricFamily)}
+}
+
+func (w *metricWriter) gauge(name, help string, value float64, labels ...label) {
+	w.add(name, help, "gauge", value, labels)
+}
+
+func (w *metricWriter) counter(name, help string, value float64, labels ...label) {
+	w.add(name, help, "counter", value, labels)
+}
+
+func (w *metricWriter) add(name, help, kind string, value float64, labels []label) {
+	name = w.namespace + "_" + name
+	family, ok := w.families[name]
+	if !ok {
+		family = &metricFamily{name: name, help: help, kind: kind}
+		w.families[name] = family
+		w.order = append(w.order, name)
+	}
+	family.samples = append(family.samples, sample{labels: labels, value: value})
+}
+
+func (w *metricWriter) bytes() []byte {
+	var buf bytes.Buffer
+	for _, name := range w.order {
+		family := w.families[name]
+		fmt.Fprintf(&buf, "# HELP %s %s\n", family.name, escapeHelp(family.help))
+		fmt.Fprintf(&buf, "# TYPE %s %s\n", family.name, family.kind)
+
+		sort.SliceStable(family.samples, func(i, j int) bool {
+			return labelString(family.samples[i].labels) < labelString(family.samples[j].labels)
+		})
+		for _, s := range family.samples {
+			fmt.Fprintf(&buf, "%s%s %s\n", family.name, labelString(s.labels), formatValue(s.value))
+		}
+	}
+	return buf.Bytes()
+}
+
+func labelString(labels []label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = l.name + `="` + escapeLabel(l.value) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
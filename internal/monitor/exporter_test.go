@@ -0,0 +1,119 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newExporterServer overview, queues, exchanges, nodes를 응답하는 Management API 서버
+func newExporterServer(t *testing.T) *RabbitMQMonitor {
+	t.Helper()
+	responses := map[string]string{
+		"/api/overview": `{"cluster_name":"rabbit@prod","queue_totals":{"messages":7,"messages_ready":5,"messages_unacknowledged":2},
+			"object_totals":{"connections":3,"channels":4,"exchanges":8,"queues":2,"consumers":1}}`,
+		"/api/queues": `[{"name":"orders.queue","vhost":"/","messages":5,"consumers":1,
+			"message_stats":{"publish":100,"publish_details":{"rate":1.5}}},
+			{"name":"say \"hi\"","vhost":"/","messages":2}]`,
+		"/api/exchanges": `[{"name":"","vhost":"/","type":"direct"},
+			{"name":"orders","vhost":"/","type":"topic","message_stats":{"publish_in":40}}]`,
+		"/api/nodes": `[{"name":"rabbit@node1","running":true,"mem_used":1024,"mem_alarm":false}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return NewRabbitMQMonitor(server.URL, "guest", "guest")
+}
+
+// scrape /metrics 응답
+func scrape(t *testing.T, e *Exporter) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestExporterCollect(t *testing.T) {
+	e := NewExporter(newExporterServer(t), ExporterConfig{})
+	if code, _ := scrape(t, e); code != http.StatusServiceUnavailable {
+		t.Fatalf("scrape before collect = %d, want 503", code)
+	}
+
+	e.Collect(context.Background())
+	code, body := scrape(t, e)
+	if code != http.StatusOK {
+		t.Fatalf("scrape = %d, want 200", code)
+	}
+
+	for _, want := range []string{
+		"# HELP rabbitmq_up Management API 수집 성공 여부\n# TYPE rabbitmq_up gauge\nrabbitmq_up 1\n",
+		`rabbitmq_messages{cluster="rabbit@prod"} 7`,
+		"# TYPE rabbitmq_queue_messages_published_total counter\n",
+		`rabbitmq_queue_messages_published_total{vhost="/",queue="orders.queue"} 100`,
+		`rabbitmq_queue_publish_rate{vhost="/",queue="orders.queue"} 1.5`,
+		`rabbitmq_queue_messages_published_total{vhost="/",queue="say \"hi\""} 0`, // message_stats 없는 큐
+		`rabbitmq_exchange_messages_published_in_total{vhost="/",exchange="orders",type="topic"} 40`,
+		`rabbitmq_node_running{node="rabbit@node1"} 1`,
+		`rabbitmq_node_mem_used_bytes{node="rabbit@node1"} 1024`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q\n%s", want, body)
+		}
+	}
+	// 기본 exchange는 이름이 없어 내보내지 않는다
+	if strings.Contains(body, `exchange=""`) {
+		t.Errorf("metrics include the default exchange\n%s", body)
+	}
+	if n := strings.Count(body, "# TYPE rabbitmq_queue_messages gauge"); n != 1 {
+		t.Errorf("TYPE line for queue_messages appears %d times, want 1", n)
+	}
+}
+
+// failingQueues 큐 목록 조회가 항상 실패하는 QueueSource
+type failingQueues struct{}
+
+func (failingQueues) ListQueues(context.Context) ([]QueueInfo, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestExporterCollectFailure(t *testing.T) {
+	e := NewExporter(newExporterServer(t), ExporterConfig{Namespace: "mq", Queues: failingQueues{}})
+	e.Collect(context.Background())
+
+	// 실패하면 이전 값 없이 up 0과 수집 시간만 남는다
+	_, body := scrape(t, e)
+	if !strings.Contains(body, "mq_up 0\n") {
+		t.Errorf("metrics missing mq_up 0\n%s", body)
+	}
+	if strings.Contains(body, "mq_messages") || strings.Contains(body, "mq_queue_") {
+		t.Errorf("metrics include values from a failed collect\n%s", body)
+	}
+}
+
+func TestFormatValueAndEscape(t *testing.T) {
+	values := map[float64]string{1: "1", 1.5: "1.5", 1e21: "1e+21", math.Inf(1): "+Inf", math.Inf(-1): "-Inf"}
+	for v, want := range values {
+		if got := formatValue(v); got != want {
+			t.Errorf("formatValue(%v) = %q, want %q", v, got, want)
+		}
+	}
+	if got := formatValue(math.NaN()); got != "NaN" {
+		t.Errorf("formatValue(NaN) = %q", got)
+	}
+	if got := escapeLabel("a\\b\n\"c\""); got != `a\\b\n\"c\"` {
+		t.Errorf("escapeLabel() = %q", got)
+	}
+	if got := escapeHelp("a\\b\n\"c\""); got != `a\\b\n"c"` {
+		t.Errorf("escapeHelp() = %q", got)
+	}
+}
@@ -0,0 +1,229 @@
+package monitor
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// QueueKey vhost와 큐 이름
+type QueueKey struct {
+	VHost string `json:"vhost"`
+	Name  string `json:"queue"`
+}
+
+// String 기본 vhost는 큐 이름만, 그 외는 vhost/큐
+func (k QueueKey) String() string {
+	if k.VHost == "/" {
+		return k.Name
+	}
+	return k.VHost + "/" + k.Name
+}
+
+// QueueSample 특정 시각의 큐 상태
+// 누적 카운터(Published 등)는 두 샘플 사이의 속도 계산에 사용한다.
+type QueueSample struct {
+	Time        time.Time `json:"time"`
+	Messages    int       `json:"messages"`
+	Ready       int       `json:"messages_ready"`
+	Unacked     int       `json:"messages_unacked"`
+	Consumers   int       `json:"consumers"`
+	Published   int       `json:"published"`
+	Delivered   int       `json:"delivered"`
+	Acked       int       `json:"acked"`
+	PublishRate float64   `json:"publish_rate"` // Management API가 계산한 순간 속도
+	DeliverRate float64   `json:"deliver_rate"`
+	AckRate     float64   `json:"ack_rate"`
+}
+
+// newQueueSample QueueInfo를 샘플로 변환
+func newQueueSample(q QueueInfo, at time.Time) QueueSample {
+	s := QueueSample{
+		Time:      at,
+		Messages:  q.Messages,
+		Ready:     q.MessagesReady,
+		Unacked:   q.MessagesUnacked,
+		Consumers: q.Consumers,
+	}
+	if stats := q.MessageStats; stats != nil {
+		s.Published = stats.Publish
+		s.Delivered = stats.Deliver
+		s.Acked = stats.Ack
+		s.PublishRate = stats.PublishDetails.Rate
+		s.DeliverRate = stats.DeliverDetails.Rate
+		s.AckRate = stats.AckDetails.Rate
+	}
+	return s
+}
+
+// sampleRing 용량이 고정된 원형 버퍼 (가득 차면 가장 오래된 샘플을 덮어씀)
+type sampleRing struct {
+	samples []QueueSample
+	start   int
+	size    int
+}
+
+func newSampleRing(capacity int) *sampleRing {
+	return &sampleRing{samples: make([]QueueSample, capacity)}
+}
+
+func (r *sampleRing) push(s QueueSample) {
+	i := (r.start + r.size) % len(r.samples)
+	r.samples[i] = s
+	if r.size < len(r.samples) {
+		r.size++
+	} else {
+		r.start = (r.start + 1) % len(r.samples)
+	}
+}
+
+// since t 이후 샘플 복사 (오래된 순)
+func (r *sampleRing) since(t time.Time) []QueueSample {
+	result := make([]QueueSample, 0, r.size)
+	for i := 0; i < r.size; i++ {
+		s := r.samples[(r.start+i)%len(r.samples)]
+		if !s.Time.Before(t) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func (r *sampleRing) last() (QueueSample, bool) {
+	if r.size == 0 {
+		return QueueSample{}, false
+	}
+	return r.samples[(r.start+r.size-1)%len(r.samples)], true
+}
+
+// ============================================
+// 샘플 저장소
+// ============================================
+
+// SampleStore 샘플을 디스크에 보관해 재시작 후에도 추세를 이어간다
+type SampleStore interface {
+	// Load 큐별 최근 샘플 최대 limit개 (오래된 순)
+	Load(limit int) (map[QueueKey][]QueueSample, error)
+	// Append 한 번의 수집 결과 저장 (큐별 limit개를 넘는 오래된 샘플은 삭제)
+	Append(samples map[QueueKey]QueueSample, limit int) error
+	// Remove 더 이상 없는 큐의 샘플 삭제
+	Remove(keys []QueueKey) error
+	Close() error
+}
+
+// BoltSampleStore bbolt 파일 기반 샘플 저장소
+// 큐마다 버킷 하나, 키는 수집 시각(UnixNano, big-endian)이라 시간 순으로 정렬된다.
+type BoltSampleStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltSampleStore 파일 저장소 열기 (없으면 생성)
+func OpenBoltSampleStore(path string) (*BoltSampleStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("샘플 저장소 열기 실패: %w", err)
+	}
+	return &BoltSampleStore{db: db}, nil
+}
+
+// 버킷 이름의 vhost/큐 구분자 (vhost와 큐 이름에 "/"가 들어갈 수 있음)
+const sampleKeySep = "\x00"
+
+func sampleBucket(key QueueKey) []byte {
+	return []byte(key.VHost + sampleKeySep + key.Name)
+}
+
+func (s *BoltSampleStore) Load(limit int) (map[QueueKey][]QueueSample, error) {
+	result := make(map[QueueKey][]QueueSample)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			vhost, queue, ok := strings.Cut(string(name), sampleKeySep)
+			if !ok {
+				return nil
+			}
+
+			// 최근 것부터 limit개를 읽고 뒤집는다
+			var samples []QueueSample
+			c := bucket.Cursor()
+			for k, v := c.Last(); k != nil && len(samples) < limit; k, v = c.Prev() {
+				var sample QueueSample
+				if err := json.Unmarshal(v, &sample); err != nil {
+					return fmt.Errorf("샘플 디코딩 실패 (%s): %w", name, err)
+				}
+				samples = append(samples, sample)
+			}
+			for i, j := 0, len(samples)-1; i < j; i, j = i+1, j-1 {
+				samples[i], samples[j] = samples[j], samples[i]
+			}
+			result[QueueKey{VHost: vhost, Name: queue}] = samples
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("샘플 로드 실패: %w", err)
+	}
+	return result, nil
+}
+
+func (s *BoltSampleStore) Append(samples map[QueueKey]QueueSample, limit int) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for key, sample := range samples {
+			bucket, err := tx.CreateBucketIfNotExists(sampleBucket(key))
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(sample)
+			if err != nil {
+				return err
+			}
+			k := make([]byte, 8)
+			binary.BigEndian.PutUint64(k, uint64(sample.Time.UnixNano()))
+			if err := bucket.Put(k, value); err != nil {
+				return err
+			}
+
+			// 오래된 샘플 정리 (삭제 후에는 First부터 다시 읽음)
+			c := bucket.Cursor()
+			count := 0
+			for k, _ := c.First(); k != nil; k, _ = c.Next() {
+				count++
+			}
+			for k, _ := c.First(); k != nil && count > limit; k, _ = c.First() {
+				if err := c.Delete(); err != nil {
+					return err
+				}
+				count--
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("샘플 저장 실패: %w", err)
+	}
+	return nil
+}
+
+func (s *BoltSampleStore) Remove(keys []QueueKey) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, key := range keys {
+			err := tx.DeleteBucket(sampleBucket(key))
+			if err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("샘플 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+func (s *BoltSampleStore) Close() error {
+	return s.db.Close()
+}
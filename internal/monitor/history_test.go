@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSampleRing(t *testing.T) {
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	at := func(i int) time.Time { return base.Add(time.Duration(i) * time.Second) }
+
+	messages := func(samples []QueueSample) []int {
+		result := make([]int, len(samples))
+		for i, s := range samples {
+			result[i] = s.Messages
+		}
+		return result
+	}
+	equal := func(a, b []int) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	tests := []struct {
+		name      string
+		capacity  int
+		pushes    int
+		since     time.Time
+		want      []int
+		wantLast  int
+		wantEmpty bool
+	}{
+		{name: "empty", capacity: 3, pushes: 0, want: []int{}, wantEmpty: true},
+		{name: "partially filled", capacity: 3, pushes: 2, want: []int{0, 1}, wantLast: 1},
+		{name: "exactly full", capacity: 3, pushes: 3, want: []int{0, 1, 2}, wantLast: 2},
+		{name: "wrapped keeps newest in order", capacity: 3, pushes: 5, want: []int{2, 3, 4}, wantLast: 4},
+		{name: "wrapped several times", capacity: 2, pushes: 7, want: []int{5, 6}, wantLast: 6},
+		{name: "capacity one", capacity: 1, pushes: 4, want: []int{3}, wantLast: 3},
+		{name: "since filters older samples", capacity: 4, pushes: 6, since: at(4), want: []int{4, 5}, wantLast: 5},
+		{name: "since after newest", capacity: 4, pushes: 3, since: at(10), want: []int{}, wantLast: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := newSampleRing(tt.capacity)
+			for i := 0; i < tt.pushes; i++ {
+				ring.push(QueueSample{Time: at(i), Messages: i})
+			}
+
+			if got := messages(ring.since(tt.since)); !equal(got, tt.want) {
+				t.Errorf("since(%v) = %v, want %v", tt.since, got, tt.want)
+			}
+
+			last, ok := ring.last()
+			if ok == tt.wantEmpty {
+				t.Fatalf("last ok = %v, want %v", ok, !tt.wantEmpty)
+			}
+			if ok && last.Messages != tt.wantLast {
+				t.Errorf("last = %d, want %d", last.Messages, tt.wantLast)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// AckMode 메시지를 가져온 뒤 처리 방식
+type AckMode string
+
+const (
+	AckRequeue    AckMode = "ack_requeue_true"     // 다시 큐에 넣음 (redelivered 표시, quorum 큐는 전달 횟수 증가)
+	AckConsume    AckMode = "ack_requeue_false"    // 큐에서 제거
+	RejectRequeue AckMode = "reject_requeue_true"  // reject 후 다시 큐에 넣음
+	RejectDrop    AckMode = "reject_requeue_false" // reject (DLX가 있으면 dead-letter)
+)
+
+// PayloadEncoding 본문 인코딩 방식
+type PayloadEncoding string
+
+const (
+	EncodingAuto   PayloadEncoding = "auto"   // UTF-8이면 문자열, 아니면 base64
+	EncodingBase64 PayloadEncoding = "base64" // 항상 base64
+)
+
+// GetMessagesOptions 메시지 조회 옵션
+type GetMessagesOptions struct {
+	Count    int             // 가져올 메시지 수 (기본 1)
+	AckMode  AckMode         // 기본 AckRequeue (소비하지 않음)
+	Encoding PayloadEncoding // 기본 EncodingAuto
+	Truncate int             // 본문을 이 바이트 수로 자름 (0이면 자르지 않음)
+}
+
+// QueueMessage Management API로 가져온 메시지
+type QueueMessage struct {
+	Exchange        string            `json:"exchange"`
+	RoutingKey      string            `json:"routing_key"`
+	Redelivered     bool              `json:"redelivered"`
+	MessageCount    int               `json:"message_count"` // 가져온 뒤 큐에 남은 메시지 수
+	Payload         string            `json:"payload"`
+	PayloadBytes    int               `json:"payload_bytes"`    // 자르기 전 본문 크기
+	PayloadEncoding string            `json:"payload_encoding"` // string 또는 base64
+	Properties      MessageProperties `json:"properties"`
+}
+
+// MessageProperties AMQP 메시지 속성
+type MessageProperties struct {
+	ContentType     string                 `json:"content_type,omitempty"`
+	ContentEncoding string                 `json:"content_encoding,omitempty"`
+	DeliveryMode    int                    `json:"delivery_mode,omitempty"` // 1: 비영속, 2: 영속
+	Priority        int                    `json:"priority,omitempty"`
+	CorrelationID   string                 `json:"correlation_id,omitempty"`
+	ReplyTo         string                 `json:"reply_to,omitempty"`
+	Expiration      string                 `json:"expiration,omitempty"`
+	MessageID       string                 `json:"message_id,omitempty"`
+	Timestamp       int64                  `json:"timestamp,omitempty"` // Unix 초
+	Type            string                 `json:"type,omitempty"`
+	UserID          string                 `json:"user_id,omitempty"`
+	AppID           string                 `json:"app_id,omitempty"`
+	Headers         map[string]interface{} `json:"headers,omitempty"`
+}
+
+// UnmarshalJSON 속성이 하나도 없는 메시지는 API가 빈 객체 대신 빈 배열([])을 보내므로 함께 허용
+func (p *MessageProperties) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			return fmt.Errorf("메시지 속성이 비어 있지 않은 배열입니다: %s", trimmed)
+		}
+		*p = MessageProperties{}
+		return nil
+	}
+	type plain MessageProperties // UnmarshalJSON 재귀 호출 방지
+	return json.Unmarshal(data, (*plain)(p))
+}
+
+// Body 본문 (base64 인코딩이면 디코딩)
+func (m QueueMessage) Body() ([]byte, error) {
+	if m.PayloadEncoding == "base64" {
+		body, err := base64.StdEncoding.DecodeString(m.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("본문 base64 디코딩 실패: %w", err)
+		}
+		return body, nil
+	}
+	return []byte(m.Payload), nil
+}
+
+// Truncated 본문이 잘렸는지 여부
+func (m QueueMessage) Truncated() bool {
+	body, err := m.Body()
+	return err == nil && len(body) < m.PayloadBytes
+}
+
+// GetMessages 큐에서 메시지 가져오기
+// 기본값(AckRequeue)은 메시지를 다시 큐에 넣으므로 큐에서 제거하지는 않지만 부작용이 없는 조회는 아니다.
+//   - 다시 넣은 메시지는 redelivered로 표시되고 큐 안의 순서가 바뀔 수 있다.
+//   - quorum 큐는 전달 횟수(x-delivery-count)가 늘어나므로 delivery-limit이 있으면
+//     반복해서 가져오는 것만으로 메시지가 dead-letter되거나 삭제될 수 있다 (QueueInfo.DeliveryLimit 참고).
+func (m *RabbitMQMonitor) GetMessages(ctx context.Context, vhost, queueName string, opts GetMessagesOptions) ([]QueueMessage, error) {
+	if opts.Count <= 0 {
+		opts.Count = 1
+	}
+	if opts.AckMode == "" {
+		opts.AckMode = AckRequeue
+	}
+	if opts.Encoding == "" {
+		opts.Encoding = EncodingAuto
+	}
+	if opts.Truncate < 0 {
+		return nil, fmt.Errorf("Truncate는 0 이상이어야 합니다: %d", opts.Truncate)
+	}
+
+	body := map[string]interface{}{
+		"count":    opts.Count,
+		"ackmode":  opts.AckMode,
+		"encoding": opts.Encoding,
+	}
+	if opts.Truncate > 0 {
+		body["truncate"] = opts.Truncate
+	}
+
+	data, err := m.post(ctx, apiPath("queues", vhost, queueName, "get"), body)
+	if err != nil {
+		return nil, fmt.Errorf("메시지 조회 실패: %w", err)
+	}
+
+	var messages []QueueMessage
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("메시지 응답 파싱 실패: %w", err)
+	}
+	return messages, nil
+}
+
+// DeliveryLimit quorum 큐의 x-delivery-limit 큐 인자 (없거나 quorum 큐가 아니면 false)
+// 정책으로 지정한 delivery-limit이나 브로커 기본값은 큐 인자에 보이지 않으므로 포함하지 않는다.
+func (q QueueInfo) DeliveryLimit() (int, bool) {
+	if !q.IsQuorum() {
+		return 0, false
+	}
+	limit, ok := q.Arguments["x-delivery-limit"].(float64)
+	if !ok || limit <= 0 {
+		return 0, false
+	}
+	return int(limit), true
+}
+
+// IsQuorum quorum 큐 여부
+func (q QueueInfo) IsQuorum() bool {
+	return q.Arguments["x-queue-type"] == "quorum"
+}
@@ -0,0 +1,182 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// getMessagesFixture 속성이 없는 메시지는 properties가 빈 배열로 온다
+const getMessagesFixture = `[
+  {
+    "payload_bytes": 12,
+    "redelivered": false,
+    "exchange": "orders",
+    "routing_key": "order.created",
+    "message_count": 2,
+    "properties": {
+      "content_type": "application/json",
+      "delivery_mode": 2,
+      "message_id": "msg-1",
+      "headers": {"schema_name": "order", "x-death": [{"count": 1, "queue": "orders.queue"}]}
+    },
+    "payload": "{\"id\":\"o-1\"}",
+    "payload_encoding": "string"
+  },
+  {
+    "payload_bytes": 4,
+    "redelivered": true,
+    "exchange": "",
+    "routing_key": "orders.queue",
+    "message_count": 1,
+    "properties": [],
+    "payload": "AAEC",
+    "payload_encoding": "base64"
+  }
+]`
+
+// newGetMessagesServer 요청 본문을 기록하고 fixture로 응답하는 Management API 서버
+func newGetMessagesServer(t *testing.T, request *map[string]interface{}) *RabbitMQMonitor {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.EscapedPath() != "/api/queues/%2F/orders.queue/get" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(getMessagesFixture))
+	}))
+	t.Cleanup(server.Close)
+	return NewRabbitMQMonitor(server.URL, "guest", "guest")
+}
+
+func TestGetMessages(t *testing.T) {
+	var request map[string]interface{}
+	m := newGetMessagesServer(t, &request)
+
+	messages, err := m.GetMessages(context.Background(), "/", "orders.queue", GetMessagesOptions{})
+	if err != nil {
+		t.Fatalf("GetMessages() error = %v", err)
+	}
+
+	// 기본값은 메시지 1개를 다시 큐에 넣는 조회
+	want := map[string]interface{}{"count": float64(1), "ackmode": "ack_requeue_true", "encoding": "auto"}
+	if len(request) != len(want) {
+		t.Errorf("request body = %v, want %v", request, want)
+	}
+	for k, v := range want {
+		if request[k] != v {
+			t.Errorf("request %s = %v, want %v", k, request[k], v)
+		}
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(messages))
+	}
+
+	first := messages[0]
+	if first.Properties.MessageID != "msg-1" || first.Properties.DeliveryMode != 2 {
+		t.Errorf("first message properties = %+v", first.Properties)
+	}
+	if first.Properties.Headers["schema_name"] != "order" {
+		t.Errorf("schema_name header = %v", first.Properties.Headers["schema_name"])
+	}
+	if body, err := first.Body(); err != nil || string(body) != `{"id":"o-1"}` {
+		t.Errorf("Body() = %q, %v", body, err)
+	}
+	if first.Truncated() {
+		t.Error("Truncated() = true for a complete body")
+	}
+
+	second := messages[1]
+	if second.Properties.MessageID != "" || second.Properties.Headers != nil {
+		t.Errorf("empty array properties = %+v, want zero value", second.Properties)
+	}
+	if body, err := second.Body(); err != nil || string(body) != "\x00\x01\x02" {
+		t.Errorf("base64 Body() = %q, %v", body, err)
+	}
+	if !second.Truncated() {
+		t.Error("Truncated() = false for a body shorter than payload_bytes")
+	}
+}
+
+func TestGetMessagesOptions(t *testing.T) {
+	var request map[string]interface{}
+	m := newGetMessagesServer(t, &request)
+
+	_, err := m.GetMessages(context.Background(), "/", "orders.queue", GetMessagesOptions{
+		Count:    5,
+		AckMode:  AckConsume,
+		Encoding: EncodingBase64,
+		Truncate: 100,
+	})
+	if err != nil {
+		t.Fatalf("GetMessages() error = %v", err)
+	}
+	want := map[string]interface{}{"count": float64(5), "ackmode": "ack_requeue_false", "encoding": "base64", "truncate": float64(100)}
+	for k, v := range want {
+		if request[k] != v {
+			t.Errorf("request %s = %v, want %v", k, request[k], v)
+		}
+	}
+
+	if _, err := m.GetMessages(context.Background(), "/", "orders.queue", GetMessagesOptions{Truncate: -1}); err == nil {
+		t.Error("Truncate < 0 error = nil, want error")
+	}
+}
+
+func TestMessagePropertiesUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string // MessageID
+		wantErr bool
+	}{
+		{"empty array", `[]`, "", false},
+		{"empty array with spaces", ` [ ] `, "", false},
+		{"empty object", `{}`, "", false},
+		{"properties", `{"message_id":"m-1"}`, "m-1", false},
+		{"non-empty array", `[1]`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p MessageProperties
+			err := json.Unmarshal([]byte(tt.data), &p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if p.MessageID != tt.want {
+				t.Errorf("MessageID = %q, want %q", p.MessageID, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueueInfoDeliveryLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		want      int
+		wantOK    bool
+	}{
+		{"quorum + limit", map[string]interface{}{"x-queue-type": "quorum", "x-delivery-limit": float64(5)}, 5, true},
+		{"quorum without limit", map[string]interface{}{"x-queue-type": "quorum"}, 0, false},
+		{"classic", map[string]interface{}{"x-delivery-limit": float64(5)}, 0, false},
+		{"no arguments", nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, ok := QueueInfo{Arguments: tt.arguments}.DeliveryLimit()
+			if limit != tt.want || ok != tt.wantOK {
+				t.Errorf("DeliveryLimit() = %d, %v, want %d, %v", limit, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// NodeInfo 클러스터 노드 정보
+type NodeInfo struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"` // disc, ram
+	Running       bool     `json:"running"`
+	Uptime        int64    `json:"uptime"` // 밀리초
+	MemUsed       int64    `json:"mem_used"`
+	MemLimit      int64    `json:"mem_limit"` // 메모리 watermark
+	MemAlarm      bool     `json:"mem_alarm"`
+	DiskFree      int64    `json:"disk_free"`
+	DiskFreeLimit int64    `json:"disk_free_limit"` // 디스크 watermark
+	DiskFreeAlarm bool     `json:"disk_free_alarm"`
+	FDUsed        int      `json:"fd_used"`
+	FDTotal       int      `json:"fd_total"`
+	SocketsUsed   int      `json:"sockets_used"`
+	SocketsTotal  int      `json:"sockets_total"`
+	ProcUsed      int      `json:"proc_used"`
+	ProcTotal     int      `json:"proc_total"`
+	Partitions    []string `json:"partitions"` // 네트워크 분할로 보이지 않는 노드
+}
+
+// MemoryUsage 메모리 watermark 대비 사용률 (0~1, 알람 시 1 이상)
+func (n NodeInfo) MemoryUsage() float64 {
+	return ratio(n.MemUsed, n.MemLimit)
+}
+
+// DiskHeadroom 디스크 여유 공간 / watermark (1 이하면 알람)
+func (n NodeInfo) DiskHeadroom() float64 {
+	return ratio(n.DiskFree, n.DiskFreeLimit)
+}
+
+// FDUsage 파일 디스크립터 사용률
+func (n NodeInfo) FDUsage() float64 {
+	return ratio(int64(n.FDUsed), int64(n.FDTotal))
+}
+
+// SocketUsage 소켓 사용률 (3.12 이후 버전은 항상 0)
+func (n NodeInfo) SocketUsage() float64 {
+	return ratio(int64(n.SocketsUsed), int64(n.SocketsTotal))
+}
+
+// HealthThresholds watermark 접근 경고 기준
+type HealthThresholds struct {
+	Memory          float64 // 메모리 watermark 대비 사용률
+	DiskHeadroom    float64 // 디스크 여유 공간이 watermark의 이 배수보다 작으면 경고
+	FileDescriptors float64
+	Sockets         float64
+}
+
+// DefaultHealthThresholds 기본 경고 기준
+var DefaultHealthThresholds = HealthThresholds{
+	Memory:          0.8,
+	DiskHeadroom:    1.5,
+	FileDescriptors: 0.8,
+	Sockets:         0.8,
+}
+
+// Warnings 알람이 발생했거나 기준에 근접한 리소스 목록
+func (n NodeInfo) Warnings(t HealthThresholds) []string {
+	var warnings []string
+	if !n.Running {
+		return []string{"노드가 실행 중이 아님"}
+	}
+	if n.MemAlarm {
+		warnings = append(warnings, fmt.Sprintf("메모리 알람 발생 (%s / %s) - publisher 차단 중", formatBytes(n.MemUsed), formatBytes(n.MemLimit)))
+	} else if n.MemoryUsage() >= t.Memory {
+		warnings = append(warnings, fmt.Sprintf("메모리 watermark의 %.0f%% 사용 (%s / %s)", n.MemoryUsage()*100, formatBytes(n.MemUsed), formatBytes(n.MemLimit)))
+	}
+	if n.DiskFreeAlarm {
+		warnings = append(warnings, fmt.Sprintf("디스크 알람 발생 (여유 %s, 기준 %s) - publisher 차단 중", formatBytes(n.DiskFree), formatBytes(n.DiskFreeLimit)))
+	} else if n.DiskFreeLimit > 0 && n.DiskHeadroom() < t.DiskHeadroom {
+		warnings = append(warnings, fmt.Sprintf("디스크 여유 공간이 watermark에 근접 (여유 %s, 기준 %s)", formatBytes(n.DiskFree), formatBytes(n.DiskFreeLimit)))
+	}
+	if n.FDUsage() >= t.FileDescriptors {
+		warnings = append(warnings, fmt.Sprintf("파일 디스크립터 %d / %d 사용", n.FDUsed, n.FDTotal))
+	}
+	if n.SocketUsage() >= t.Sockets {
+		warnings = append(warnings, fmt.Sprintf("소켓 %d / %d 사용", n.SocketsUsed, n.SocketsTotal))
+	}
+	if len(n.Partitions) > 0 {
+		warnings = append(warnings, fmt.Sprintf("네트워크 분할 감지: %v", n.Partitions))
+	}
+	return warnings
+}
+
+// ListNodes 클러스터 노드 목록 조회
+func (m *RabbitMQMonitor) ListNodes(ctx context.Context) ([]NodeInfo, error) {
+	var nodes []NodeInfo
+	if err := m.getJSON(ctx, apiPath("nodes"), &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// HealthCheck Management API health check 결과
+type HealthCheck struct {
+	Name   string // alarms, local-alarms, virtual-hosts
+	OK     bool
+	Reason string // 실패 사유
+}
+
+// 지원하는 health check
+const (
+	HealthCheckAlarms       = "alarms"        // 클러스터 전체 리소스 알람
+	HealthCheckLocalAlarms  = "local-alarms"  // 요청을 받은 노드의 리소스 알람
+	HealthCheckVirtualHosts = "virtual-hosts" // 모든 vhost 실행 여부
+)
+
+// CheckHealth health check 하나 실행 (503 응답은 에러가 아니라 실패 결과)
+func (m *RabbitMQMonitor) CheckHealth(ctx context.Context, name string) (HealthCheck, error) {
+	check := HealthCheck{Name: name}
+
+	// 실패 상태를 재시도해도 결과가 같으므로 한 번만 요청
+	_, err := m.do(ctx, http.MethodGet, apiPath("health", "checks", name), nil)
+	var apiErr *APIError
+	switch {
+	case err == nil:
+		check.OK = true
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable:
+		check.Reason = apiErr.Reason
+	default:
+		return check, err
+	}
+	return check, nil
+}
+
+// ClusterHealth 클러스터 상태 요약
+type ClusterHealth struct {
+	Nodes    []NodeInfo
+	Checks   []HealthCheck
+	Warnings map[string][]string // 노드 이름별 경고
+}
+
+// Healthy 실패한 health check와 경고가 없는지
+func (h *ClusterHealth) Healthy() bool {
+	for _, check := range h.Checks {
+		if !check.OK {
+			return false
+		}
+	}
+	return len(h.Warnings) == 0
+}
+
+// GetClusterHealth 노드 상태와 health check를 모아 요약
+func (m *RabbitMQMonitor) GetClusterHealth(ctx context.Context, thresholds HealthThresholds) (*ClusterHealth, error) {
+	nodes, err := m.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &ClusterHealth{Nodes: nodes, Warnings: make(map[string][]string)}
+	for _, node := range nodes {
+		if warnings := node.Warnings(thresholds); len(warnings) > 0 {
+			health.Warnings[node.Name] = warnings
+		}
+	}
+
+	for _, name := range []string{HealthCheckAlarms, HealthCheckLocalAlarms, HealthCheckVirtualHosts} {
+		check, err := m.CheckHealth(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("health check %s 실패: %w", name, err)
+		}
+		health.Checks = append(health.Checks, check)
+	}
+	return health, nil
+}
+
+// FormatUptime 노드 uptime을 사람이 읽는 형식으로
+func (n NodeInfo) FormatUptime() string {
+	return (time.Duration(n.Uptime) * time.Millisecond).Truncate(time.Minute).String()
+}
+
+func ratio(used, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(used) / float64(total)
+}
+
+// formatBytes 바이트를 KiB/MiB/GiB 단위로
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -1,10 +1,15 @@
 package monitor
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -32,6 +37,8 @@ type QueueInfo struct {
 	Name           string `json:"name"`
 	VHost          string `json:"vhost"`
 	Durable        bool   `json:"durable"`
+	AutoDelete     bool   `json:"auto_delete"`
+	Arguments      map[string]interface{} `json:"arguments,omitempty"`
 	Messages       int    `json:"messages"`
 	MessagesReady  int    `json:"messages_ready"`
 	MessagesUnacked int   `json:"messages_unacknowledged"`
@@ -63,15 +70,33 @@ type ExchangeInfo struct {
 	Type       string `json:"type"`
 	Durable    bool   `json:"durable"`
 	AutoDelete bool   `json:"auto_delete"`
+	Internal   bool   `json:"internal"`
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`
+	// Message Stats
+	MessageStats *ExchangeMessageStats `json:"message_stats,omitempty"`
+}
+
+// ExchangeMessageStats Exchange로 들어오고 나간 메시지 통계
+type ExchangeMessageStats struct {
+	PublishIn        int `json:"publish_in"`
+	PublishInDetails struct {
+		Rate float64 `json:"rate"`
+	} `json:"publish_in_details"`
+	PublishOut        int `json:"publish_out"`
+	PublishOutDetails struct {
+		Rate float64 `json:"rate"`
+	} `json:"publish_out_details"`
 }
 
 // BindingInfo 바인딩 정보
 type BindingInfo struct {
-	Source          string `json:"source"`
-	VHost           string `json:"vhost"`
-	Destination     string `json:"destination"`
-	DestinationType string `json:"destination_type"`
-	RoutingKey      string `json:"routing_key"`
+	Source          string                 `json:"source"`
+	VHost           string                 `json:"vhost"`
+	Destination     string                 `json:"destination"`
+	DestinationType string                 `json:"destination_type"`
+	RoutingKey      string                 `json:"routing_key"`
+	Arguments       map[string]interface{} `json:"arguments,omitempty"`
+	PropertiesKey   string                 `json:"properties_key"` // 바인딩 삭제 시 경로에 사용
 }
 
 // Overview 전체 개요
@@ -93,115 +118,173 @@ type Overview struct {
 	} `json:"object_totals"`
 }
 
-func (m *RabbitMQMonitor) request(endpoint string) ([]byte, error) {
-	req, err := http.NewRequest("GET", m.baseURL+endpoint, nil)
+// GET 재시도 설정 (연결 실패, 429, 5xx만 재시도)
+const (
+	maxGetAttempts = 3
+	retryBaseDelay = 200 * time.Millisecond
+)
+
+// apiPath 경로 세그먼트를 각각 이스케이프해 API 경로 생성
+// 기본 vhost "/"는 %2F가 되어야 하고, 큐 이름에도 "/", "#" 등이 들어갈 수 있다.
+func apiPath(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = url.PathEscape(s)
+	}
+	return "/api/" + strings.Join(escaped, "/")
+}
+
+// request GET 요청 (실패 시 지수 백오프로 재시도)
+func (m *RabbitMQMonitor) request(ctx context.Context, endpoint string) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt < maxGetAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(retryBaseDelay << (attempt - 1)):
+			}
+		}
+
+		data, err := m.do(ctx, http.MethodGet, endpoint, nil)
+		if err == nil {
+			return data, nil
+		}
+		lastErr = err
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && !apiErr.retryable() {
+			return nil, err
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return nil, lastErr
+}
+
+// post JSON 본문과 함께 POST 요청 (재시도하지 않음)
+func (m *RabbitMQMonitor) post(ctx context.Context, endpoint string, body interface{}) ([]byte, error) {
+	return m.do(ctx, http.MethodPost, endpoint, body)
+}
+
+// do 요청 한 번 실행 (body가 nil이 아니면 JSON으로 전송)
+func (m *RabbitMQMonitor) do(ctx context.Context, method, endpoint string, body interface{}) ([]byte, error) {
+	return m.send(ctx, method, endpoint, body, nil)
+}
+
+// send 추가 헤더와 함께 요청 한 번 실행
+func (m *RabbitMQMonitor) send(ctx context.Context, method, endpoint string, body interface{}, header http.Header) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("요청 직렬화 실패: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, m.baseURL+endpoint, reader)
 	if err != nil {
 		return nil, err
 	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
 	req.SetBasicAuth(m.username, m.password)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	resp, err := m.client.Do(req)
 	if err != nil {
-		return nil, err
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &ConnectionError{Endpoint: endpoint, Err: err}
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API 요청 실패: %d", resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &ConnectionError{Endpoint: endpoint, Err: err}
 	}
 
-	return io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{Method: method, Endpoint: endpoint, StatusCode: resp.StatusCode}
+		var reason struct {
+			Reason string `json:"reason"`
+		}
+		if json.Unmarshal(data, &reason) == nil {
+			apiErr.Reason = reason.Reason
+		}
+		return nil, apiErr
+	}
+	return data, nil
 }
 
-// GetOverview 전체 개요 조회
-func (m *RabbitMQMonitor) GetOverview() (*Overview, error) {
-	data, err := m.request("/api/overview")
+// getJSON GET 요청 후 응답을 v로 디코딩
+func (m *RabbitMQMonitor) getJSON(ctx context.Context, endpoint string, v interface{}) error {
+	data, err := m.request(ctx, endpoint)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("응답 파싱 실패 (%s): %w", endpoint, err)
+	}
+	return nil
+}
 
+// GetOverview 전체 개요 조회
+func (m *RabbitMQMonitor) GetOverview(ctx context.Context) (*Overview, error) {
 	var overview Overview
-	if err := json.Unmarshal(data, &overview); err != nil {
+	if err := m.getJSON(ctx, apiPath("overview"), &overview); err != nil {
 		return nil, err
 	}
 	return &overview, nil
 }
 
 // ListQueues 모든 큐 목록 조회
-func (m *RabbitMQMonitor) ListQueues() ([]QueueInfo, error) {
-	data, err := m.request("/api/queues")
-	if err != nil {
-		return nil, err
-	}
-
+func (m *RabbitMQMonitor) ListQueues(ctx context.Context) ([]QueueInfo, error) {
 	var queues []QueueInfo
-	if err := json.Unmarshal(data, &queues); err != nil {
+	if err := m.getJSON(ctx, apiPath("queues"), &queues); err != nil {
 		return nil, err
 	}
 	return queues, nil
 }
 
-// GetQueue 특정 큐 조회
-func (m *RabbitMQMonitor) GetQueue(vhost, name string) (*QueueInfo, error) {
-	endpoint := fmt.Sprintf("/api/queues/%s/%s", vhost, name)
-	data, err := m.request(endpoint)
-	if err != nil {
-		return nil, err
-	}
-
+// GetQueue 특정 큐 조회 (없으면 ErrNotFound)
+func (m *RabbitMQMonitor) GetQueue(ctx context.Context, vhost, name string) (*QueueInfo, error) {
 	var queue QueueInfo
-	if err := json.Unmarshal(data, &queue); err != nil {
+	if err := m.getJSON(ctx, apiPath("queues", vhost, name), &queue); err != nil {
 		return nil, err
 	}
 	return &queue, nil
 }
 
 // ListExchanges 모든 Exchange 목록 조회
-func (m *RabbitMQMonitor) ListExchanges() ([]ExchangeInfo, error) {
-	data, err := m.request("/api/exchanges")
-	if err != nil {
-		return nil, err
-	}
-
+func (m *RabbitMQMonitor) ListExchanges(ctx context.Context) ([]ExchangeInfo, error) {
 	var exchanges []ExchangeInfo
-	if err := json.Unmarshal(data, &exchanges); err != nil {
+	if err := m.getJSON(ctx, apiPath("exchanges"), &exchanges); err != nil {
 		return nil, err
 	}
 	return exchanges, nil
 }
 
 // ListBindings 모든 바인딩 목록 조회
-func (m *RabbitMQMonitor) ListBindings() ([]BindingInfo, error) {
-	data, err := m.request("/api/bindings")
-	if err != nil {
-		return nil, err
-	}
-
+func (m *RabbitMQMonitor) ListBindings(ctx context.Context) ([]BindingInfo, error) {
 	var bindings []BindingInfo
-	if err := json.Unmarshal(data, &bindings); err != nil {
+	if err := m.getJSON(ctx, apiPath("bindings"), &bindings); err != nil {
 		return nil, err
 	}
 	return bindings, nil
 }
 
-// GetMessages 큐에서 메시지 가져오기 (peek, 소비하지 않음)
-func (m *RabbitMQMonitor) GetMessages(vhost, queueName string, count int) ([]map[string]interface{}, error) {
-	endpoint := fmt.Sprintf("/api/queues/%s/%s/get", vhost, queueName)
-
-	// POST 요청 필요
-	reqBody := fmt.Sprintf(`{"count":%d,"ackmode":"ack_requeue_true","encoding":"auto"}`, count)
-
-	req, err := http.NewRequest("POST", m.baseURL+endpoint, nil)
-	if err != nil {
+// ListQueueBindings 특정 큐의 바인딩 목록 조회
+func (m *RabbitMQMonitor) ListQueueBindings(ctx context.Context, vhost, queueName string) ([]BindingInfo, error) {
+	var bindings []BindingInfo
+	if err := m.getJSON(ctx, apiPath("queues", vhost, queueName, "bindings"), &bindings); err != nil {
 		return nil, err
 	}
-	req.SetBasicAuth(m.username, m.password)
-	req.Header.Set("Content-Type", "application/json")
-	req.Body = io.NopCloser(io.Reader(nil))
-
-	// 간단 구현을 위해 빈 배열 반환
-	// 실제로는 POST body와 함께 요청해야 함
-	_ = reqBody
-
-	return nil, nil
+	return bindings, nil
 }
@@ -0,0 +1,443 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"rabbit-mq-with-go/internal/alert"
+)
+
+// 기본 평가 주기
+const defaultRuleInterval = 30 * time.Second
+
+// AlertRule 큐 메트릭 알림 규칙
+// 예: queue: orders.queue, expr: "consumers == 0", for: 2m
+type AlertRule struct {
+	Name        string         `yaml:"name"`
+	VHost       string         `yaml:"vhost"`       // 빈 값이면 모든 vhost
+	Queue       string         `yaml:"queue"`       // 큐 이름 또는 glob 패턴 (orders.*)
+	Expr        string         `yaml:"expr"`        // <메트릭> <비교> <숫자|메트릭|숫자 * 메트릭>
+	For         time.Duration  `yaml:"for"`         // 조건이 이 시간 동안 계속 참이어야 발생
+	Severity    alert.Severity `yaml:"severity"`    // 기본 warning
+	Description string         `yaml:"description"` // 알림 본문에 추가할 설명
+	Notify      []string       `yaml:"notify"`      // 보낼 notifier 이름 (빈 값이면 전체)
+
+	cond condition
+}
+
+// AlertState 규칙 평가 상태
+type AlertState string
+
+const (
+	AlertPending AlertState = "pending" // 조건이 참이지만 For가 지나지 않음
+	AlertFiring  AlertState = "firing"  // 알림 발생 중
+)
+
+// ActiveAlert pending 또는 firing 상태인 규칙
+type ActiveAlert struct {
+	Rule   string
+	VHost  string
+	Queue  string
+	State  AlertState
+	Since  time.Time // 조건이 처음 참이 된 시각
+	Values map[string]float64
+}
+
+// RuleEngine 주기적으로 큐 메트릭을 읽어 규칙을 평가하고, 상태가 바뀔 때만 알림을 보낸다
+// pending → firing 때 한 번, firing → 해소 때 한 번 (Resolved) 보낸다.
+type RuleEngine struct {
+	queues   QueueSource
+	rules    []AlertRule
+	sinks    map[string]alert.Sink
+	interval time.Duration
+
+	mu     sync.Mutex
+	active map[string]*ActiveAlert // 규칙/vhost/큐별 상태
+}
+
+// NewRuleEngine 규칙 검증 후 엔진 생성 (notifiers는 이름별 Sink)
+func NewRuleEngine(queues QueueSource, interval time.Duration, rules []AlertRule, notifiers map[string]alert.Sink) (*RuleEngine, error) {
+	if len(notifiers) == 0 {
+		return nil, errors.New("notifier가 하나 이상 필요합니다")
+	}
+	if interval <= 0 {
+		interval = defaultRuleInterval
+	}
+
+	names := make(map[string]bool)
+	parsed := make([]AlertRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("규칙 %d: 이름이 필요합니다", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("규칙 %s: 이름이 중복됩니다", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if rule.Queue == "" {
+			return nil, fmt.Errorf("규칙 %s: queue가 필요합니다", rule.Name)
+		}
+		if _, err := path.Match(rule.Queue, ""); err != nil {
+			return nil, fmt.Errorf("규칙 %s: queue 패턴이 잘못되었습니다: %w", rule.Name, err)
+		}
+		cond, err := parseCondition(rule.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("규칙 %s: %w", rule.Name, err)
+		}
+		rule.cond = cond
+		for _, name := range rule.Notify {
+			if _, ok := notifiers[name]; !ok {
+				return nil, fmt.Errorf("규칙 %s: 알 수 없는 notifier %q", rule.Name, name)
+			}
+		}
+		if rule.Severity == "" {
+			rule.Severity = alert.SeverityWarning
+		}
+		parsed = append(parsed, rule)
+	}
+
+	return &RuleEngine{
+		queues:   queues,
+		rules:    parsed,
+		sinks:    notifiers,
+		interval: interval,
+		active:   make(map[string]*ActiveAlert),
+	}, nil
+}
+
+// Run ctx가 끝날 때까지 주기적으로 평가
+func (e *RuleEngine) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.Evaluate(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[❌] 알림 규칙 평가 실패: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Evaluate 큐 목록을 한 번 읽어 모든 규칙 평가
+// 조회에 실패하면 상태를 바꾸지 않는다 (API 장애를 해소로 보지 않음).
+func (e *RuleEngine) Evaluate(ctx context.Context) error {
+	queues, err := e.queues.ListQueues(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	e.mu.Lock()
+	var notifications []notification
+	seen := make(map[string]bool)
+	for _, rule := range e.rules {
+		for _, q := range queues {
+			if !rule.matches(q) {
+				continue
+			}
+			key := rule.Name + "|" + q.VHost + "|" + q.Name
+			seen[key] = true
+
+			ok, values := rule.cond.eval(q)
+			if n, send := e.transition(key, rule, q, ok, values, now); send {
+				notifications = append(notifications, n)
+			}
+		}
+	}
+
+	// 삭제된 큐의 알림은 해소로 처리
+	for key, a := range e.active {
+		if seen[key] {
+			continue
+		}
+		if a.State == AlertFiring {
+			rule := e.rule(a.Rule)
+			notifications = append(notifications, notification{rule: rule, alert: resolvedAlert(rule, a, now, "큐가 더 이상 없음")})
+		}
+		delete(e.active, key)
+	}
+	e.mu.Unlock()
+
+	for _, n := range notifications {
+		e.notify(ctx, n)
+	}
+	return nil
+}
+
+// Active 현재 pending/firing 상태인 알림 (규칙, 큐 이름 순)
+func (e *RuleEngine) Active() []ActiveAlert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make([]ActiveAlert, 0, len(e.active))
+	for _, a := range e.active {
+		result = append(result, *a)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Rule != result[j].Rule {
+			return result[i].Rule < result[j].Rule
+		}
+		return result[i].Queue < result[j].Queue
+	})
+	return result
+}
+
+type notification struct {
+	rule  AlertRule
+	alert alert.Alert
+}
+
+// transition 평가 결과로 상태를 바꾸고, 알림을 보내야 하면 반환 (mu를 잡은 상태에서 호출)
+func (e *RuleEngine) transition(key string, rule AlertRule, q QueueInfo, ok bool, values map[string]float64, now time.Time) (notification, bool) {
+	a, exists := e.active[key]
+	if !ok {
+		if !exists {
+			return notification{}, false
+		}
+		delete(e.active, key)
+		if a.State != AlertFiring {
+			return notification{}, false
+		}
+		a.Values = values
+		return notification{rule: rule, alert: resolvedAlert(rule, a, now, "")}, true
+	}
+
+	if !exists {
+		a = &ActiveAlert{Rule: rule.Name, VHost: q.VHost, Queue: q.Name, State: AlertPending, Since: now}
+		e.active[key] = a
+	}
+	a.Values = values
+	if a.State == AlertFiring || now.Sub(a.Since) < rule.For {
+		return notification{}, false
+	}
+	a.State = AlertFiring
+	return notification{rule: rule, alert: firingAlert(rule, a, now)}, true
+}
+
+func (e *RuleEngine) rule(name string) AlertRule {
+	for _, rule := range e.rules {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	return AlertRule{Name: name}
+}
+
+// notify 규칙의 notifier로 전송 (실패는 로그만 남김)
+func (e *RuleEngine) notify(ctx context.Context, n notification) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	names := n.rule.Notify
+	if len(names) == 0 {
+		for name := range e.sinks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	if n.alert.Resolved {
+		log.Printf("[✅] %s", n.alert.Title)
+	} else {
+		log.Printf("[🚨] %s", n.alert.Title)
+	}
+	for _, name := range names {
+		if err := e.sinks[name].Send(ctx, n.alert); err != nil {
+			log.Printf("[❌] 알림 전송 실패 (%s → %s): %v", n.alert.Key, name, err)
+		}
+	}
+}
+
+func firingAlert(rule AlertRule, a *ActiveAlert, now time.Time) alert.Alert {
+	message := fmt.Sprintf("조건 %q이(가) %s부터 계속 참입니다. 현재 값: %s",
+		rule.Expr, a.Since.Format(time.RFC3339), formatValues(a.Values))
+	if rule.Description != "" {
+		message = rule.Description + "\n" + message
+	}
+	return alert.Alert{
+		Key:       alertKey(rule, a),
+		Title:     fmt.Sprintf("%s: %s (%s)", rule.Name, a.Queue, rule.Expr),
+		Message:   message,
+		Severity:  rule.Severity,
+		Labels:    map[string]string{"rule": rule.Name, "vhost": a.VHost, "queue": a.Queue},
+		Timestamp: now,
+	}
+}
+
+func resolvedAlert(rule AlertRule, a *ActiveAlert, now time.Time, reason string) alert.Alert {
+	message := fmt.Sprintf("조건 %q이(가) 해소되었습니다 (%s 동안 지속). 현재 값: %s",
+		rule.Expr, now.Sub(a.Since).Truncate(time.Second), formatValues(a.Values))
+	if reason != "" {
+		message = fmt.Sprintf("조건 %q이(가) 해소되었습니다: %s", rule.Expr, reason)
+	}
+	return alert.Alert{
+		Key:       alertKey(rule, a),
+		Title:     fmt.Sprintf("%s 해소: %s", rule.Name, a.Queue),
+		Message:   message,
+		Severity:  rule.Severity,
+		Labels:    map[string]string{"rule": rule.Name, "vhost": a.VHost, "queue": a.Queue},
+		Timestamp: now,
+		Resolved:  true,
+	}
+}
+
+func alertKey(rule AlertRule, a *ActiveAlert) string {
+	return "rule:" + rule.Name + ":" + a.VHost + "/" + a.Queue
+}
+
+func formatValues(values map[string]float64) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%s", name, strconv.FormatFloat(values[name], 'f', -1, 64))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// matches 규칙 대상 큐인지
+func (r AlertRule) matches(q QueueInfo) bool {
+	if r.VHost != "" && r.VHost != q.VHost {
+		return false
+	}
+	ok, _ := path.Match(r.Queue, q.Name)
+	return ok
+}
+
+// queueMetrics 규칙 식에서 사용할 수 있는 큐 메트릭
+var queueMetrics = map[string]func(QueueInfo) float64{
+	"messages":         func(q QueueInfo) float64 { return float64(q.Messages) },
+	"messages_ready":   func(q QueueInfo) float64 { return float64(q.MessagesReady) },
+	"messages_unacked": func(q QueueInfo) float64 { return float64(q.MessagesUnacked) },
+	"consumers":        func(q QueueInfo) float64 { return float64(q.Consumers) },
+	"publish_rate": func(q QueueInfo) float64 {
+		if q.MessageStats == nil {
+			return 0
+		}
+		return q.MessageStats.PublishDetails.Rate
+	},
+	"deliver_rate": func(q QueueInfo) float64 {
+		if q.MessageStats == nil {
+			return 0
+		}
+		return q.MessageStats.DeliverDetails.Rate
+	},
+	"ack_rate": func(q QueueInfo) float64 {
+		if q.MessageStats == nil {
+			return 0
+		}
+		return q.MessageStats.AckDetails.Rate
+	},
+}
+
+// operand 식의 한쪽 (factor * metric, metric이 비면 상수)
+type operand struct {
+	factor float64
+	metric string
+}
+
+func (o operand) eval(q QueueInfo, values map[string]float64) float64 {
+	if o.metric == "" {
+		return o.factor
+	}
+	v := queueMetrics[o.metric](q)
+	values[o.metric] = v
+	return o.factor * v
+}
+
+// condition "<operand> <비교> <operand>"
+type condition struct {
+	left, right operand
+	op          string
+}
+
+func (c condition) eval(q QueueInfo) (bool, map[string]float64) {
+	values := make(map[string]float64)
+	l, r := c.left.eval(q, values), c.right.eval(q, values)
+	switch c.op {
+	case ">":
+		return l > r, values
+	case ">=":
+		return l >= r, values
+	case "<":
+		return l < r, values
+	case "<=":
+		return l <= r, values
+	case "==":
+		return l == r, values
+	default: // !=
+		return l != r, values
+	}
+}
+
+// parseCondition 식 파싱
+// 예: "messages > 0", "consumers == 0", "publish_rate > 5 * ack_rate"
+func parseCondition(expr string) (condition, error) {
+	for _, op := range []string{">=", "<=", "==", "!=", ">", "<"} {
+		i := strings.Index(expr, op)
+		if i < 0 {
+			continue
+		}
+		left, err := parseOperand(expr[:i])
+		if err != nil {
+			return condition{}, fmt.Errorf("식 %q: %w", expr, err)
+		}
+		right, err := parseOperand(expr[i+len(op):])
+		if err != nil {
+			return condition{}, fmt.Errorf("식 %q: %w", expr, err)
+		}
+		return condition{left: left, right: right, op: op}, nil
+	}
+	return condition{}, fmt.Errorf("식 %q: 비교 연산자(>, >=, <, <=, ==, !=)가 필요합니다", expr)
+}
+
+func parseOperand(s string) (operand, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return operand{}, errors.New("비교 대상이 비어 있습니다")
+	}
+
+	o := operand{factor: 1}
+	for _, part := range strings.Split(s, "*") {
+		part = strings.TrimSpace(part)
+		if n, err := strconv.ParseFloat(part, 64); err == nil {
+			o.factor *= n
+			continue
+		}
+		if _, ok := queueMetrics[part]; !ok {
+			return operand{}, fmt.Errorf("알 수 없는 메트릭 %q (%s)", part, metricNames())
+		}
+		if o.metric != "" {
+			return operand{}, fmt.Errorf("메트릭끼리 곱할 수 없습니다: %q", s)
+		}
+		o.metric = part
+	}
+	return o, nil
+}
+
+func metricNames() string {
+	names := make([]string, 0, len(queueMetrics))
+	for name := range queueMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
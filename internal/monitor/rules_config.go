@@ -0,0 +1,202 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"rabbit-mq-with-go/internal/alert"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RulesConfig 알림 규칙 YAML 설정
+//
+//	interval: 30s
+//	notifiers:
+//	  - name: ops
+//	    type: webhook
+//	    url: https://hooks.example.com/rabbitmq
+//	rules:
+//	  - name: orders-dlq-not-empty
+//	    queue: orders.dlq
+//	    expr: messages > 0
+//	    severity: critical
+//	  - name: orders-no-consumer
+//	    queue: orders.queue
+//	    expr: consumers == 0
+//	    for: 2m
+type RulesConfig struct {
+	Interval  time.Duration    `yaml:"interval"`
+	Notifiers []NotifierConfig `yaml:"notifiers"`
+	Rules     []AlertRule      `yaml:"rules"`
+}
+
+// NotifierConfig 알림을 보낼 대상 (type별로 사용하는 필드가 다름)
+type NotifierConfig struct {
+	Name string `yaml:"name"` // 규칙의 notify에서 참조 (기본 type)
+	Type string `yaml:"type"` // log, webhook, smtp 또는 RegisterNotifier로 등록한 타입
+
+	Path     string            `yaml:"path"`    // log
+	URL      string            `yaml:"url"`     // webhook
+	Headers  map[string]string `yaml:"headers"` // webhook
+	Addr     string            `yaml:"addr"`    // smtp
+	Username string            `yaml:"username"`
+	Password string            `yaml:"password"`
+	From     string            `yaml:"from"`
+	To       []string          `yaml:"to"`
+
+	Options map[string]string `yaml:"options"` // 직접 등록한 타입용
+}
+
+// NotifierFactory 설정으로 Sink 생성 (Close가 필요하면 io.Closer도 구현)
+type NotifierFactory func(config NotifierConfig) (alert.Sink, error)
+
+var (
+	notifierMu        sync.RWMutex
+	notifierFactories = map[string]NotifierFactory{
+		"log": func(c NotifierConfig) (alert.Sink, error) {
+			if c.Path == "" {
+				return nil, errors.New("path가 필요합니다")
+			}
+			return alert.OpenLogFileSink(c.Path)
+		},
+		"webhook": func(c NotifierConfig) (alert.Sink, error) {
+			if c.URL == "" {
+				return nil, errors.New("url이 필요합니다")
+			}
+			return alert.NewWebhookSink(c.URL, c.Headers), nil
+		},
+		"smtp": func(c NotifierConfig) (alert.Sink, error) {
+			return alert.NewSMTPSink(alert.SMTPConfig{
+				Addr:     c.Addr,
+				Username: c.Username,
+				Password: c.Password,
+				From:     c.From,
+				To:       c.To,
+			})
+		},
+	}
+)
+
+// RegisterNotifier notifier 타입 등록 (Slack, PagerDuty 등)
+func RegisterNotifier(kind string, factory NotifierFactory) {
+	notifierMu.Lock()
+	defer notifierMu.Unlock()
+	notifierFactories[kind] = factory
+}
+
+// LoadRulesConfig YAML 파일 읽기
+// 값에 쓴 ${SMTP_PASSWORD} 같은 환경 변수는 YAML을 파싱한 뒤 치환한다.
+// 치환한 값이 YAML 구조를 바꾸지 않도록 파일 전체가 아닌 값 하나하나를 치환하고,
+// $VAR 형태나 설정되지 않은 변수는 그대로 둔다 (비밀번호, 헤더 값의 $ 보존).
+func LoadRulesConfig(path string) (*RulesConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("알림 규칙 파일 읽기 실패: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("알림 규칙 파싱 실패 (%s): %w", path, err)
+	}
+	expandEnvNode(&doc)
+
+	var config RulesConfig
+	if err := doc.Decode(&config); err != nil {
+		return nil, fmt.Errorf("알림 규칙 파싱 실패 (%s): %w", path, err)
+	}
+	return &config, nil
+}
+
+// envPattern 치환할 환경 변수 (${NAME} 형태만)
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnvNode 스칼라 값의 ${NAME}을 환경 변수로 치환 (매핑 키는 그대로)
+func expandEnvNode(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		expanded := expandEnv(node.Value)
+		if expanded == node.Value {
+			return
+		}
+		node.Value = expanded
+		if node.Style == 0 && node.Tag == "!!str" {
+			// 따옴표 없는 ${SMTP_PORT}가 숫자나 시간 필드에도 들어갈 수 있도록 타입을 다시 판별
+			node.Tag = ""
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			expandEnvNode(node.Content[i])
+		}
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			expandEnvNode(child)
+		}
+	}
+}
+
+// expandEnv 설정된 환경 변수만 치환
+func expandEnv(s string) string {
+	return envPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if value, ok := os.LookupEnv(ref[2 : len(ref)-1]); ok {
+			return value
+		}
+		return ref
+	})
+}
+
+// NewRuleEngineFromConfig 설정의 notifier를 만들고 엔진 생성
+// 반환된 close 함수로 notifier(로그 파일 등)를 닫는다.
+func NewRuleEngineFromConfig(queues QueueSource, config *RulesConfig) (*RuleEngine, func() error, error) {
+	sinks := make(map[string]alert.Sink)
+	var closers []io.Closer
+	closeAll := func() error {
+		var errs []error
+		for _, c := range closers {
+			if err := c.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	for i, nc := range config.Notifiers {
+		if nc.Name == "" {
+			nc.Name = nc.Type
+		}
+		if _, dup := sinks[nc.Name]; dup {
+			closeAll()
+			return nil, nil, fmt.Errorf("notifier %s: 이름이 중복됩니다", nc.Name)
+		}
+
+		notifierMu.RLock()
+		factory, ok := notifierFactories[nc.Type]
+		notifierMu.RUnlock()
+		if !ok {
+			closeAll()
+			return nil, nil, fmt.Errorf("notifier %d: 알 수 없는 타입 %q", i, nc.Type)
+		}
+
+		sink, err := factory(nc)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("notifier %s 생성 실패: %w", nc.Name, err)
+		}
+		sinks[nc.Name] = sink
+		if c, ok := sink.(io.Closer); ok {
+			closers = append(closers, c)
+		}
+	}
+
+	engine, err := NewRuleEngine(queues, config.Interval, config.Rules, sinks)
+	if err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+	return engine, closeAll, nil
+}
@@ -0,0 +1,65 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadRulesConfigExpandsEnv(t *testing.T) {
+	t.Setenv("RULES_TEST_PASSWORD", "p@ss: #1\nrules: []")
+	t.Setenv("RULES_TEST_INTERVAL", "45s")
+	t.Setenv("RULES_TEST_TOKEN", "secret")
+
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	data := `interval: ${RULES_TEST_INTERVAL}
+notifiers:
+  - name: mail
+    type: smtp
+    addr: smtp.example.com:587
+    password: ${RULES_TEST_PASSWORD}
+    from: $HOME@example.com
+    to: [ops@example.com]
+  - name: hook
+    type: webhook
+    url: https://hooks.example.com/${RULES_TEST_UNSET}
+    headers:
+      Authorization: "Bearer ${RULES_TEST_TOKEN}"
+rules:
+  - name: orders-dlq-not-empty
+    queue: orders.dlq
+    expr: messages > 0
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadRulesConfig(path)
+	if err != nil {
+		t.Fatalf("LoadRulesConfig() error = %v", err)
+	}
+
+	if config.Interval != 45*time.Second {
+		t.Errorf("Interval = %v, want 45s", config.Interval)
+	}
+	// 값에 YAML 문법이 들어 있어도 구조가 바뀌지 않는다
+	if len(config.Rules) != 1 || len(config.Notifiers) != 2 {
+		t.Fatalf("rules = %d, notifiers = %d, want 1 and 2", len(config.Rules), len(config.Notifiers))
+	}
+	mail, hook := config.Notifiers[0], config.Notifiers[1]
+	if mail.Password != "p@ss: #1\nrules: []" {
+		t.Errorf("Password = %q", mail.Password)
+	}
+	// $VAR 형태와 설정되지 않은 변수는 그대로 남는다
+	if mail.From != "$HOME@example.com" {
+		t.Errorf("From = %q, want $HOME kept", mail.From)
+	}
+	if hook.URL != "https://hooks.example.com/${RULES_TEST_UNSET}" {
+		t.Errorf("URL = %q, want unset variable kept", hook.URL)
+	}
+	if want := map[string]string{"Authorization": "Bearer secret"}; !reflect.DeepEqual(hook.Headers, want) {
+		t.Errorf("Headers = %v, want %v", hook.Headers, want)
+	}
+}
@@ -0,0 +1,79 @@
+package monitor
+
+import "testing"
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    condition
+		wantErr bool
+	}{
+		{expr: "messages > 0", want: condition{left: operand{1, "messages"}, right: operand{0, ""}, op: ">"}},
+		{expr: "messages>=100", want: condition{left: operand{1, "messages"}, right: operand{100, ""}, op: ">="}},
+		{expr: "consumers == 0", want: condition{left: operand{1, "consumers"}, right: operand{0, ""}, op: "=="}},
+		{expr: "consumers != 1", want: condition{left: operand{1, "consumers"}, right: operand{1, ""}, op: "!="}},
+		{expr: "ack_rate <= 0.5", want: condition{left: operand{1, "ack_rate"}, right: operand{0.5, ""}, op: "<="}},
+		{expr: "messages_ready < 10", want: condition{left: operand{1, "messages_ready"}, right: operand{10, ""}, op: "<"}},
+		{
+			expr: "publish_rate > 2 * ack_rate",
+			want: condition{left: operand{1, "publish_rate"}, right: operand{2, "ack_rate"}, op: ">"},
+		},
+		{
+			expr: "0.5 * messages * 2 < messages_unacked",
+			want: condition{left: operand{1, "messages"}, right: operand{1, "messages_unacked"}, op: "<"},
+		},
+
+		{expr: "messages", wantErr: true},
+		{expr: "messages >", wantErr: true},
+		{expr: "> 0", wantErr: true},
+		{expr: "queue_depth > 0", wantErr: true},
+		{expr: "messages * consumers > 0", wantErr: true},
+		{expr: "messages = 0", wantErr: true},
+		{expr: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseCondition(tt.expr)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseCondition(%q) = %+v, want error", tt.expr, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseCondition(%q) error: %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseCondition(%q) = %+v, want %+v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestConditionEval(t *testing.T) {
+	q := QueueInfo{Messages: 10, Consumers: 0, MessageStats: &MessageStats{}}
+	q.MessageStats.PublishDetails.Rate = 5
+	q.MessageStats.AckDetails.Rate = 2
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"messages > 0", true},
+		{"messages >= 10", true},
+		{"messages < 10", false},
+		{"consumers == 0", true},
+		{"publish_rate > 2 * ack_rate", true},
+		{"publish_rate > 3 * ack_rate", false},
+		{"ack_rate != 2", false},
+	}
+	for _, tt := range tests {
+		cond, err := parseCondition(tt.expr)
+		if err != nil {
+			t.Fatalf("parseCondition(%q): %v", tt.expr, err)
+		}
+		if got, _ := cond.eval(q); got != tt.want {
+			t.Errorf("%q on %+v = %v, want %v", tt.expr, q, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 기본 수집 주기와 큐별 보관 샘플 수 (10초 간격으로 1시간)
+const (
+	defaultSampleInterval = 10 * time.Second
+	defaultSampleCapacity = 360
+)
+
+// 마지막 수집 결과가 이 주기 수만큼 갱신되지 않으면 ListQueues가 에러를 반환한다
+const sampleStaleIntervals = 3
+
+// QueueSource 큐 목록 조회 (RabbitMQMonitor 또는 Sampler의 마지막 수집 결과)
+type QueueSource interface {
+	ListQueues(ctx context.Context) ([]QueueInfo, error)
+}
+
+// SamplerConfig 샘플러 설정
+type SamplerConfig struct {
+	Interval time.Duration // 수집 주기 (기본 10s)
+	Capacity int           // 큐별 보관 샘플 수 (기본 360)
+	Store    SampleStore   // 디스크 보관 (nil이면 메모리만)
+}
+
+// Sampler 큐 상태를 주기적으로 수집해 큐별 원형 버퍼에 보관하고 추세를 계산한다
+type Sampler struct {
+	monitor *RabbitMQMonitor
+	config  SamplerConfig
+
+	mu       sync.RWMutex
+	queues   map[QueueKey]*sampleRing
+	latest   []QueueInfo // 마지막 수집 결과 (ListQueues로 공유)
+	latestAt time.Time
+	ready    chan struct{} // 첫 수집이 성공하면 닫힘
+}
+
+// NewSampler 샘플러 생성 (Store가 있으면 이전 샘플을 불러옴)
+func NewSampler(monitor *RabbitMQMonitor, config SamplerConfig) (*Sampler, error) {
+	if config.Interval <= 0 {
+		config.Interval = defaultSampleInterval
+	}
+	if config.Capacity <= 0 {
+		config.Capacity = defaultSampleCapacity
+	}
+
+	s := &Sampler{
+		monitor: monitor,
+		config:  config,
+		queues:  make(map[QueueKey]*sampleRing),
+		ready:   make(chan struct{}),
+	}
+	if config.Store != nil {
+		loaded, err := config.Store.Load(config.Capacity)
+		if err != nil {
+			return nil, err
+		}
+		for key, samples := range loaded {
+			ring := newSampleRing(config.Capacity)
+			for _, sample := range samples {
+				ring.push(sample)
+			}
+			s.queues[key] = ring
+		}
+		// 꺼져 있는 동안 삭제된 큐의 샘플은 불러오자마자 정리
+		if err := config.Store.Remove(s.expire(time.Now())); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Run ctx가 끝날 때까지 주기적으로 수집
+func (s *Sampler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sample(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[❌] 큐 샘플 수집 실패: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample 큐 목록을 한 번 읽어 샘플 추가
+func (s *Sampler) Sample(ctx context.Context) error {
+	queues, err := s.monitor.ListQueues(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	round := make(map[QueueKey]QueueSample, len(queues))
+	s.mu.Lock()
+	s.latest, s.latestAt = queues, now
+	select {
+	case <-s.ready:
+	default:
+		close(s.ready)
+	}
+	for _, q := range queues {
+		key := QueueKey{VHost: q.VHost, Name: q.Name}
+		sample := newQueueSample(q, now)
+		round[key] = sample
+
+		ring, ok := s.queues[key]
+		if !ok {
+			ring = newSampleRing(s.config.Capacity)
+			s.queues[key] = ring
+		}
+		ring.push(sample)
+	}
+
+	expired := s.expire(now)
+	s.mu.Unlock()
+
+	if s.config.Store == nil {
+		return nil
+	}
+	if err := s.config.Store.Append(round, s.config.Capacity); err != nil {
+		return err
+	}
+	if len(expired) > 0 {
+		return s.config.Store.Remove(expired)
+	}
+	return nil
+}
+
+// expire 보관 기간 동안 수집되지 않은 (삭제된) 큐 정리 (s.mu를 잡은 상태로 호출)
+func (s *Sampler) expire(now time.Time) []QueueKey {
+	retention := time.Duration(s.config.Capacity) * s.config.Interval
+	var expired []QueueKey
+	for key, ring := range s.queues {
+		if last, ok := ring.last(); !ok || now.Sub(last.Time) > retention {
+			delete(s.queues, key)
+			expired = append(expired, key)
+		}
+	}
+	return expired
+}
+
+// ListQueues 마지막 수집 결과 (QueueSource 구현)
+// exporter와 알림 규칙이 Management API를 따로 호출하지 않고 샘플러의 수집 결과를 함께 쓴다.
+// 첫 수집 전이면 기다리고, 수집이 계속 실패해 결과가 오래되었으면 에러를 반환한다.
+func (s *Sampler) ListQueues(ctx context.Context) ([]QueueInfo, error) {
+	select {
+	case <-s.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if age := time.Since(s.latestAt); age > sampleStaleIntervals*s.config.Interval {
+		return nil, fmt.Errorf("마지막 큐 수집이 %s 전입니다", age.Round(time.Second))
+	}
+	return append([]QueueInfo(nil), s.latest...), nil
+}
+
+// Queues 샘플이 있는 큐 목록 (이름 순)
+func (s *Sampler) Queues() []QueueKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]QueueKey, 0, len(s.queues))
+	for key := range s.queues {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].VHost != keys[j].VHost {
+			return keys[i].VHost < keys[j].VHost
+		}
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
+
+// History 최근 window 동안의 샘플 (오래된 순, window <= 0이면 전체)
+func (s *Sampler) History(key QueueKey, window time.Duration) []QueueSample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ring, ok := s.queues[key]
+	if !ok {
+		return nil
+	}
+	var since time.Time
+	if window > 0 {
+		since = time.Now().Add(-window)
+	}
+	return ring.since(since)
+}
+
+// QueueTrend 최근 구간의 큐 추세
+type QueueTrend struct {
+	QueueKey
+	Samples     int     `json:"samples"`
+	Span        float64 `json:"span_seconds"` // 첫 샘플과 마지막 샘플 사이 시간
+	Messages    int     `json:"messages"`     // 마지막 샘플 기준
+	Consumers   int     `json:"consumers"`
+	DepthRate   float64 `json:"depth_rate"` // 초당 메시지 수 변화 (음수면 줄어드는 중)
+	PublishRate float64 `json:"publish_rate"`
+	DeliverRate float64 `json:"deliver_rate"`
+	AckRate     float64 `json:"ack_rate"`
+	// 현재 ack 속도로 큐가 비워지기까지 남은 시간 (초, 줄어들지 않으면 -1)
+	DrainSeconds float64 `json:"drain_eta_seconds"`
+}
+
+// DrainETA 큐가 비워지기까지 남은 시간 (줄어들지 않으면 false)
+func (t QueueTrend) DrainETA() (time.Duration, bool) {
+	if t.DrainSeconds < 0 {
+		return 0, false
+	}
+	return time.Duration(t.DrainSeconds * float64(time.Second)), true
+}
+
+func (t QueueTrend) String() string {
+	eta := "줄어들지 않음"
+	if d, ok := t.DrainETA(); ok {
+		eta = "약 " + d.Round(time.Second).String() + " 후 비워짐"
+	}
+	return fmt.Sprintf("%s: %d건 (publish %.1f/s, ack %.1f/s) → %s", t.QueueKey, t.Messages, t.PublishRate, t.AckRate, eta)
+}
+
+// Trend 최근 window 동안의 추세 계산 (샘플이 없으면 false)
+// 속도는 누적 카운터 차이로 계산하고, 카운터가 초기화되었거나 샘플이 하나뿐이면
+// Management API가 계산한 마지막 순간 속도를 사용한다.
+func (s *Sampler) Trend(key QueueKey, window time.Duration) (QueueTrend, bool) {
+	samples := s.History(key, window)
+	if len(samples) == 0 {
+		return QueueTrend{}, false
+	}
+	return computeTrend(key, samples), true
+}
+
+// Trends 모든 큐의 추세 (이름 순)
+func (s *Sampler) Trends(window time.Duration) []QueueTrend {
+	var trends []QueueTrend
+	for _, key := range s.Queues() {
+		if trend, ok := s.Trend(key, window); ok {
+			trends = append(trends, trend)
+		}
+	}
+	return trends
+}
+
+func computeTrend(key QueueKey, samples []QueueSample) QueueTrend {
+	first, last := samples[0], samples[len(samples)-1]
+	trend := QueueTrend{
+		QueueKey:    key,
+		Samples:     len(samples),
+		Messages:    last.Messages,
+		Consumers:   last.Consumers,
+		PublishRate: last.PublishRate,
+		DeliverRate: last.DeliverRate,
+		AckRate:     last.AckRate,
+	}
+
+	span := last.Time.Sub(first.Time).Seconds()
+	trend.Span = span
+	if span > 0 {
+		trend.DepthRate = float64(last.Messages-first.Messages) / span
+		trend.PublishRate = counterRate(first.Published, last.Published, span, last.PublishRate)
+		trend.DeliverRate = counterRate(first.Delivered, last.Delivered, span, last.DeliverRate)
+		trend.AckRate = counterRate(first.Acked, last.Acked, span, last.AckRate)
+	}
+
+	drain := trend.AckRate - trend.PublishRate
+	switch {
+	case last.Messages == 0:
+		trend.DrainSeconds = 0
+	case drain > 0:
+		trend.DrainSeconds = math.Round(float64(last.Messages)/drain*10) / 10
+	default:
+		trend.DrainSeconds = -1
+	}
+	return trend
+}
+
+// counterRate 누적 카운터의 초당 증가량 (카운터가 줄었으면 fallback)
+func counterRate(from, to int, seconds, fallback float64) float64 {
+	if to < from {
+		return fallback
+	}
+	return float64(to-from) / seconds
+}
+
+// Handler 다른 도구에서 이력과 추세를 조회하는 HTTP API
+//
+//	GET /history?vhost=/&queue=orders.queue&window=10m
+//	GET /trends?window=5m
+func (s *Sampler) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/history", func(w http.ResponseWriter, r *http.Request) {
+		window, err := windowParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		key := QueueKey{VHost: r.URL.Query().Get("vhost"), Name: r.URL.Query().Get("queue")}
+		if key.VHost == "" {
+			key.VHost = "/"
+		}
+		samples := s.History(key, window)
+		if samples == nil {
+			http.Error(w, fmt.Sprintf("샘플이 없는 큐: %s", key), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, samples)
+	})
+	mux.HandleFunc("/trends", func(w http.ResponseWriter, r *http.Request) {
+		window, err := windowParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		trends := s.Trends(window)
+		if trends == nil {
+			trends = []QueueTrend{}
+		}
+		writeJSON(w, trends)
+	})
+	return mux
+}
+
+// windowParam window 쿼리 파라미터 (기본 5m)
+func windowParam(r *http.Request) (time.Duration, error) {
+	value := r.URL.Query().Get("window")
+	if value == "" {
+		return 5 * time.Minute, nil
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("window 형식 오류: %w", err)
+	}
+	return window, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
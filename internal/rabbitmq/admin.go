@@ -0,0 +1,128 @@
+package rabbitmq
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// adminResponse 관리 API 공통 응답
+type adminResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// AdminHandler 실행 중인 Consumer 제어용 HTTP 핸들러
+//
+//	GET  /status    현재 상태
+//	POST /pause     소비 일시 중지
+//	POST /resume    소비 재개
+//	POST /prefetch  prefetch 변경 ({"prefetch": 20})
+func AdminHandler(c *Consumer) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeAdmin(w, http.StatusMethodNotAllowed, adminResponse{Error: "GET만 지원합니다"})
+			return
+		}
+		writeAdmin(w, http.StatusOK, adminResponse{Success: true, Data: c.Status()})
+	})
+
+	mux.HandleFunc("/pause", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeAdmin(w, http.StatusMethodNotAllowed, adminResponse{Error: "POST만 지원합니다"})
+			return
+		}
+		if err := c.Pause(); err != nil {
+			writeAdmin(w, http.StatusInternalServerError, adminResponse{Error: err.Error()})
+			return
+		}
+		writeAdmin(w, http.StatusOK, adminResponse{Success: true, Data: c.Status()})
+	})
+
+	mux.HandleFunc("/resume", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeAdmin(w, http.StatusMethodNotAllowed, adminResponse{Error: "POST만 지원합니다"})
+			return
+		}
+		c.Resume()
+		writeAdmin(w, http.StatusOK, adminResponse{Success: true, Data: c.Status()})
+	})
+
+	mux.HandleFunc("/prefetch", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeAdmin(w, http.StatusMethodNotAllowed, adminResponse{Error: "POST만 지원합니다"})
+			return
+		}
+
+		var req struct {
+			Prefetch *int `json:"prefetch"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Prefetch == nil {
+			writeAdmin(w, http.StatusBadRequest, adminResponse{Error: `요청 형식: {"prefetch": 20}`})
+			return
+		}
+		if err := c.SetPrefetch(*req.Prefetch); err != nil {
+			writeAdmin(w, http.StatusBadRequest, adminResponse{Error: err.Error()})
+			return
+		}
+		writeAdmin(w, http.StatusOK, adminResponse{Success: true, Data: c.Status()})
+	})
+
+	return mux
+}
+
+// RequireToken Authorization: Bearer <token> 헤더가 일치하는 요청만 통과 (token이 비어 있으면 검사 안 함)
+func RequireToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeAdmin(w, http.StatusUnauthorized, adminResponse{Error: "인증이 필요합니다"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ServeAdmin 관리 API 서버를 백그라운드로 시작
+// token이 있으면 Authorization: Bearer <token>을 요구한다. 토큰 없이는 loopback 주소에서만 연다.
+func ServeAdmin(addr, token string, c *Consumer) {
+	if token == "" && !isLoopback(addr) {
+		log.Printf("[❌] 관리 API 시작 안 함: %s는 외부에서 접근 가능한 주소라 토큰이 필요합니다", addr)
+		return
+	}
+	go func() {
+		log.Printf("[🛠️] 관리 API 시작: http://%s (status, pause, resume, prefetch)", addr)
+		if err := http.ListenAndServe(addr, RequireToken(token, AdminHandler(c))); err != nil {
+			log.Printf("[❌] 관리 API 종료: %v", err)
+		}
+	}()
+}
+
+// isLoopback 주소가 로컬에서만 접근 가능한지 (":8081"처럼 host가 비어 있으면 모든 인터페이스)
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func writeAdmin(w http.ResponseWriter, status int, resp adminResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
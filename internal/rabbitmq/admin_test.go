@@ -0,0 +1,102 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminHandler(t *testing.T) {
+	c := newControlTestConsumer()
+	handler := AdminHandler(c)
+
+	tests := []struct {
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantPaused bool
+	}{
+		{http.MethodGet, "/status", "", http.StatusOK, false},
+		{http.MethodPost, "/status", "", http.StatusMethodNotAllowed, false},
+		{http.MethodGet, "/pause", "", http.StatusMethodNotAllowed, false},
+		{http.MethodPost, "/pause", "", http.StatusOK, true},
+		{http.MethodPost, "/resume", "", http.StatusOK, false},
+		{http.MethodPost, "/prefetch", `{"size": 20}`, http.StatusBadRequest, false},
+		{http.MethodPost, "/prefetch", `{"prefetch": -1}`, http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.wantStatus {
+			t.Errorf("%s %s = %d, want %d (%s)", tt.method, tt.path, rec.Code, tt.wantStatus, rec.Body)
+			continue
+		}
+		var resp struct {
+			Success bool           `json:"success"`
+			Data    ConsumerStatus `json:"data"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Errorf("%s %s: invalid JSON: %v", tt.method, tt.path, err)
+			continue
+		}
+		if resp.Success != (tt.wantStatus == http.StatusOK) {
+			t.Errorf("%s %s: success = %v", tt.method, tt.path, resp.Success)
+		}
+		if resp.Success && resp.Data.Paused != tt.wantPaused {
+			t.Errorf("%s %s: paused = %v, want %v", tt.method, tt.path, resp.Data.Paused, tt.wantPaused)
+		}
+	}
+}
+
+func TestRequireToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	handler := RequireToken("s3cret", ok)
+
+	tests := []struct {
+		auth string
+		want int
+	}{
+		{"", http.StatusUnauthorized},
+		{"s3cret", http.StatusUnauthorized},
+		{"Bearer wrong", http.StatusUnauthorized},
+		{"Bearer s3cret", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("Authorization %q = %d, want %d", tt.auth, rec.Code, tt.want)
+		}
+	}
+
+	if RequireToken("", ok) == nil {
+		t.Error("RequireToken with empty token returned nil")
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1:8081": true,
+		"localhost:8081": true,
+		"[::1]:8081":     true,
+		":8081":          false,
+		"0.0.0.0:8081":   false,
+		"10.0.0.5:8081":  false,
+		"8081":           false,
+	}
+	for addr, want := range tests {
+		if got := isLoopback(addr); got != want {
+			t.Errorf("isLoopback(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package rabbitmq
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ArchivedMessage 보관된 dead letter
+type ArchivedMessage struct {
+	ID          string                 `json:"id"`
+	ArchivedAt  time.Time              `json:"archived_at"`
+	Queue       string                 `json:"queue"`                  // 보관 시점의 큐 (DLQ)
+	Exchange    string                 `json:"exchange"`               // 원래 exchange
+	RoutingKeys []string               `json:"routing_keys,omitempty"` // 원래 routing key
+	OriginQueue string                 `json:"origin_queue,omitempty"` // dead-letter 되기 전 큐
+	Reason      string                 `json:"reason,omitempty"`
+	DeadAt      time.Time              `json:"dead_at,omitempty"`
+	MessageID   string                 `json:"message_id,omitempty"`
+	ContentType string                 `json:"content_type,omitempty"`
+	Headers     map[string]interface{} `json:"headers,omitempty"`
+	Deaths      []DeathInfo            `json:"deaths,omitempty"`
+	Body        json.RawMessage        `json:"body,omitempty"`     // JSON 본문은 그대로
+	RawBody     []byte                 `json:"raw_body,omitempty"` // JSON이 아닌 본문 (base64)
+}
+
+// ArchiveQuery 보관 메시지 검색 조건 (비어 있는 조건은 검사하지 않음)
+type ArchiveQuery struct {
+	OriginQueue string
+	Reason      string
+	MessageID   string
+	Since       time.Time // ArchivedAt 기준
+	Until       time.Time
+	Limit       int
+}
+
+// DeadLetterArchive dead letter 보관소
+type DeadLetterArchive interface {
+	// Append 보관 (같은 ID가 이미 있으면 무시하고 false)
+	Append(msg ArchivedMessage) (bool, error)
+	Query(q ArchiveQuery) ([]ArchivedMessage, error)
+	Close() error
+}
+
+// NewArchivedMessage 수신한 dead letter를 보관 형식으로 변환
+func NewArchivedMessage(queue string, msg amqp.Delivery) ArchivedMessage {
+	archived := ArchivedMessage{
+		ArchivedAt:  time.Now(),
+		Queue:       queue,
+		MessageID:   msg.MessageId,
+		ContentType: msg.ContentType,
+		Headers:     msg.Headers,
+	}
+	if origin, ok := originOf(msg); ok {
+		archived.Exchange = origin.exchange
+		archived.RoutingKeys = origin.routingKeys
+		archived.OriginQueue = origin.queue
+		archived.Reason = origin.reason
+		archived.DeadAt = origin.time
+	}
+	archived.Deaths, _ = ParseDeaths(msg.Headers)
+
+	if json.Valid(msg.Body) {
+		archived.Body = msg.Body
+	} else {
+		archived.RawBody = msg.Body
+	}
+	archived.ID = archiveID(msg, archived)
+	return archived
+}
+
+// archiveID 같은 dead letter를 DLQ에서 여러 번 읽어도 같은 값
+// 다시 dead-letter 되면 x-death 횟수가 늘어 새로 보관된다.
+// message ID와 dead-letter 시각이 모두 없으면 같은 메시지인지 알 수 없으므로,
+// 서로 다른 메시지를 중복으로 버리지 않도록 delivery tag와 보관 시각을 섞는다 (같은 메시지가 다시 보관될 수 있음).
+func archiveID(msg amqp.Delivery, archived ArchivedMessage) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%d\x00", msg.MessageId, archived.OriginQueue,
+		archived.DeadAt.UTC().Format(time.RFC3339Nano), deathCount(msg))
+	if msg.MessageId == "" && archived.DeadAt.IsZero() {
+		fmt.Fprintf(h, "%d\x00%d\x00", msg.DeliveryTag, archived.ArchivedAt.UnixNano())
+	}
+	h.Write(msg.Body)
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// ArchiveDeadLetters 처리 전 모든 dead letter를 보관하는 미들웨어
+func ArchiveDeadLetters(archive DeadLetterArchive, queue string) Middleware {
+	return func(next ContextHandler) ContextHandler {
+		return func(ctx context.Context, delivery amqp.Delivery) error {
+			if _, err := archive.Append(NewArchivedMessage(queue, delivery)); err != nil {
+				// 보관 실패로 메시지를 버리지 않도록 큐로 되돌린다
+				return fmt.Errorf("%w: dead letter 보관 실패: %v", errRequeue, err)
+			}
+			return next(ctx, delivery)
+		}
+	}
+}
+
+// 중복 판별용 보관 ID를 기억하는 기간
+// 이보다 오래 DLQ에 남아 있는 메시지는 다시 보관될 수 있다 (parking-lot MaxAge를 이보다 짧게 둔다).
+const archiveDedupWindow = 7 * 24 * time.Hour
+
+// NDJSONArchive 날짜별 NDJSON 파일(dead-letters-YYYY-MM-DD.ndjson)로 보관
+type NDJSONArchive struct {
+	dir  string
+	mu   sync.Mutex
+	seen map[string]string // 보관 ID → 보관 날짜 (archiveDedupWindow 동안만 유지)
+	file *os.File
+	day  string
+}
+
+// OpenNDJSONArchive 디렉터리를 열고 최근 archiveDedupWindow 동안의 보관 ID를 읽어 들인다
+func OpenNDJSONArchive(dir string) (*NDJSONArchive, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("보관 디렉터리 생성 실패: %w", err)
+	}
+
+	a := &NDJSONArchive{dir: dir, seen: make(map[string]string)}
+	err := a.scan(time.Now().Add(-archiveDedupWindow), time.Time{}, func(msg ArchivedMessage) bool {
+		a.seen[msg.ID] = archiveDay(msg.ArchivedAt)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Append 보관 (같은 ID가 이미 있으면 무시)
+func (a *NDJSONArchive) Append(msg ArchivedMessage) (bool, error) {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return false, fmt.Errorf("보관 메시지 직렬화 실패: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.seen[msg.ID]; ok {
+		return false, nil
+	}
+
+	day := archiveDay(msg.ArchivedAt)
+	if a.file == nil || a.day != day {
+		if a.file != nil {
+			a.file.Close()
+		}
+		a.forget(msg.ArchivedAt.Add(-archiveDedupWindow))
+		f, err := os.OpenFile(a.path(day), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			a.file = nil
+			return false, fmt.Errorf("보관 파일 열기 실패: %w", err)
+		}
+		a.file, a.day = f, day
+	}
+
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		return false, fmt.Errorf("보관 파일 쓰기 실패: %w", err)
+	}
+	a.seen[msg.ID] = day
+	return true, nil
+}
+
+// forget before 이전 날짜에 보관한 ID를 중복 판별 대상에서 제외 (파일이 바뀔 때 호출)
+func (a *NDJSONArchive) forget(before time.Time) {
+	cutoff := archiveDay(before)
+	for id, day := range a.seen {
+		if day < cutoff {
+			delete(a.seen, id)
+		}
+	}
+}
+
+// Query 조건에 맞는 보관 메시지 (보관 순서)
+func (a *NDJSONArchive) Query(q ArchiveQuery) ([]ArchivedMessage, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var result []ArchivedMessage
+	err := a.scan(q.Since, q.Until, func(msg ArchivedMessage) bool {
+		if q.match(msg) {
+			result = append(result, msg)
+		}
+		return q.Limit == 0 || len(result) < q.Limit
+	})
+	return result, err
+}
+
+// Close 열린 파일 닫기
+func (a *NDJSONArchive) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// archiveDay 보관 파일 날짜 (YYYY-MM-DD, 문자열 비교로 순서 비교 가능)
+func archiveDay(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
+
+func (a *NDJSONArchive) path(day string) string {
+	return filepath.Join(a.dir, "dead-letters-"+day+".ndjson")
+}
+
+// scan 기간에 해당하는 날짜 파일을 순서대로 읽는다 (fn이 false를 반환하면 중단)
+func (a *NDJSONArchive) scan(since, until time.Time, fn func(ArchivedMessage) bool) error {
+	files, err := filepath.Glob(filepath.Join(a.dir, "dead-letters-*.ndjson"))
+	if err != nil {
+		return fmt.Errorf("보관 파일 목록 조회 실패: %w", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		day := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "dead-letters-"), ".ndjson")
+		if !since.IsZero() && day < since.Local().Format("2006-01-02") {
+			continue
+		}
+		if !until.IsZero() && day > until.Local().Format("2006-01-02") {
+			continue
+		}
+
+		more, err := scanFile(file, fn)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+	return nil
+}
+
+func scanFile(path string, fn func(ArchivedMessage) bool) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("보관 파일 열기 실패: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var msg ArchivedMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			// 쓰다 중단된 마지막 줄 등은 건너뛴다
+			continue
+		}
+		if !fn(msg) {
+			return false, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("보관 파일 읽기 실패 (%s): %w", path, err)
+	}
+	return true, nil
+}
+
+func (q ArchiveQuery) match(msg ArchivedMessage) bool {
+	if q.OriginQueue != "" && msg.OriginQueue != q.OriginQueue {
+		return false
+	}
+	if q.Reason != "" && msg.Reason != q.Reason {
+		return false
+	}
+	if q.MessageID != "" && msg.MessageID != q.MessageID {
+		return false
+	}
+	if !q.Since.IsZero() && msg.ArchivedAt.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !msg.ArchivedAt.Before(q.Until) {
+		return false
+	}
+	return true
+}
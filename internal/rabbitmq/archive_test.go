@@ -0,0 +1,146 @@
+package rabbitmq
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// testDeadLetter x-death 항목 하나가 있는 dead letter
+func testDeadLetter(messageID string, count int64, deadAt time.Time, tag uint64) amqp.Delivery {
+	return amqp.Delivery{
+		MessageId:   messageID,
+		DeliveryTag: tag,
+		ContentType: "application/json",
+		Body:        []byte(`{"id":"o-1"}`),
+		Headers: amqp.Table{HeaderDeath: []interface{}{amqp.Table{
+			"count": count, "reason": "rejected", "queue": "orders.queue",
+			"exchange": "orders", "routing-keys": []interface{}{"order.created"}, "time": deadAt,
+		}}},
+	}
+}
+
+func TestArchiveID(t *testing.T) {
+	deadAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	id := func(msg amqp.Delivery) string {
+		return NewArchivedMessage("orders.dlq", msg).ID
+	}
+
+	first := id(testDeadLetter("m-1", 1, deadAt, 1))
+	if again := id(testDeadLetter("m-1", 1, deadAt, 7)); again != first {
+		t.Errorf("reading the same dead letter again changed the ID: %s != %s", again, first)
+	}
+	if redead := id(testDeadLetter("m-1", 2, deadAt, 1)); redead == first {
+		t.Error("a higher x-death count should give a new ID")
+	}
+	if other := id(testDeadLetter("m-2", 1, deadAt, 1)); other == first {
+		t.Error("a different message ID should give a new ID")
+	}
+
+	// message ID 없이도 dead-letter 시각이 있으면 다시 읽어도 같은 ID
+	noID := id(testDeadLetter("", 1, deadAt, 1))
+	if again := id(testDeadLetter("", 1, deadAt, 2)); again != noID {
+		t.Errorf("dead letter without message ID changed ID between reads: %s != %s", again, noID)
+	}
+
+	// 식별할 수 있는 값이 없으면 같은 본문이라도 서로 다른 ID
+	plain := amqp.Delivery{DeliveryTag: 1, Body: []byte("same")}
+	other := amqp.Delivery{DeliveryTag: 2, Body: []byte("same")}
+	if id(plain) == id(other) {
+		t.Error("dead letters without message ID and time collided")
+	}
+}
+
+func TestNDJSONArchive(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := OpenNDJSONArchive(dir)
+	if err != nil {
+		t.Fatalf("OpenNDJSONArchive() error = %v", err)
+	}
+
+	deadAt := time.Now().Add(-time.Hour)
+	orders := NewArchivedMessage("orders.dlq", testDeadLetter("m-1", 1, deadAt, 1))
+	payments := NewArchivedMessage("payments.dlq", amqp.Delivery{
+		MessageId: "p-1",
+		Body:      []byte("not json"),
+		Headers: amqp.Table{
+			HeaderOriginalExchange:   "payments",
+			HeaderOriginalRoutingKey: "payment.failed",
+			HeaderOriginalQueue:      "payments.queue",
+			HeaderDeadLetteredAt:     deadAt,
+		},
+	})
+
+	for i, tt := range []struct {
+		msg  ArchivedMessage
+		want bool
+	}{
+		{orders, true},
+		{payments, true},
+		{NewArchivedMessage("orders.dlq", testDeadLetter("m-1", 1, deadAt, 9)), false}, // 같은 dead letter
+	} {
+		added, err := archive.Append(tt.msg)
+		if err != nil {
+			t.Fatalf("Append #%d error = %v", i, err)
+		}
+		if added != tt.want {
+			t.Errorf("Append #%d = %v, want %v", i, added, tt.want)
+		}
+	}
+
+	all, err := archive.Query(ArchiveQuery{})
+	if err != nil || len(all) != 2 {
+		t.Fatalf("Query() = %d messages, %v, want 2", len(all), err)
+	}
+	if string(all[0].Body) != `{"id":"o-1"}` || string(all[1].RawBody) != "not json" {
+		t.Errorf("bodies = %s / %q, want JSON body and raw body", all[0].Body, all[1].RawBody)
+	}
+
+	tests := []struct {
+		name  string
+		query ArchiveQuery
+		want  []string // MessageID
+	}{
+		{"origin queue", ArchiveQuery{OriginQueue: "payments.queue"}, []string{"p-1"}},
+		{"reason", ArchiveQuery{Reason: "rejected"}, []string{"m-1", "p-1"}},
+		{"message ID", ArchiveQuery{MessageID: "m-1"}, []string{"m-1"}},
+		{"limit", ArchiveQuery{Limit: 1}, []string{"m-1"}},
+		{"until before archive", ArchiveQuery{Until: time.Now().Add(-time.Minute)}, nil},
+		{"since after archive", ArchiveQuery{Since: time.Now().Add(time.Minute)}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := archive.Query(tt.query)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			var ids []string
+			for _, msg := range got {
+				ids = append(ids, msg.MessageID)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("Query() = %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Errorf("Query() = %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// 다시 열어도 이미 보관한 ID는 중복으로 판별
+	reopened, err := OpenNDJSONArchive(dir)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	defer reopened.Close()
+	if added, err := reopened.Append(orders); err != nil || added {
+		t.Errorf("Append after reopen = %v, %v, want false (already archived)", added, err)
+	}
+}
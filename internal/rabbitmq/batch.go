@@ -0,0 +1,208 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// 배치 모드 기본값
+const (
+	defaultBatchSize = 100
+	defaultBatchWait = time.Second
+)
+
+// BatchHandler 여러 메시지를 한 번에 처리하는 함수 타입
+// 일부만 실패했다면 *BatchError로 실패한 메시지를 알려주면 해당 메시지만 NACK된다.
+// 그 외의 에러는 배치 전체 실패로 처리된다.
+type BatchHandler func(deliveries []amqp.Delivery) error
+
+// BatchError 배치 중 일부 메시지 처리 실패
+type BatchError struct {
+	Failed map[int]error // 배치 내 인덱스 → 실패 원인
+}
+
+// NewBatchError 빈 배치 에러 생성
+func NewBatchError() *BatchError {
+	return &BatchError{Failed: make(map[int]error)}
+}
+
+// Add 실패한 메시지 인덱스 추가
+func (e *BatchError) Add(index int, err error) {
+	e.Failed[index] = err
+}
+
+// ErrOrNil 실패한 메시지가 없으면 nil 반환
+func (e *BatchError) ErrOrNil() error {
+	if len(e.Failed) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("배치 중 %d개 메시지 처리 실패", len(e.Failed))
+}
+
+// ConsumeBatch 배치 모드로 메시지 소비
+// BatchSize개가 모이거나 첫 메시지 수신 후 BatchWait이 지나면 handler를 호출한다.
+// 미들웨어는 메시지 단위이므로 배치 모드에는 적용되지 않는다.
+// stream 큐는 오프셋을 메시지 단위로 추적하므로 배치 모드를 지원하지 않는다.
+// multiple=true로 ACK하므로 BatchSize나 BatchWait을 설정해 전용 채널로 만든 Consumer에서만 사용할 수 있다.
+func (c *Consumer) ConsumeBatch(ctx context.Context, handler BatchHandler) error {
+	if c.isStream() {
+		return errors.New("stream 큐는 배치 모드를 지원하지 않습니다")
+	}
+	if !c.config.batchMode() {
+		return errors.New("배치 모드는 BatchSize 또는 BatchWait을 설정한 Consumer에서만 사용할 수 있습니다")
+	}
+
+	size := c.config.BatchSize
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+	wait := c.config.BatchWait
+	if wait <= 0 {
+		wait = defaultBatchWait
+	}
+
+	// prefetch보다 큰 배치는 절대 채워지지 않으므로 prefetch에 맞춘다
+	if prefetch := c.currentPrefetch(); prefetch > 0 && size > prefetch {
+		log.Printf("[⚠️] BatchSize(%d)가 PrefetchCount(%d)보다 커서 %d로 조정합니다",
+			size, prefetch, prefetch)
+		size = prefetch
+	}
+
+	msgs, err := c.subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	batch := make([]amqp.Delivery, 0, size)
+	timer := time.NewTimer(wait)
+	timer.Stop()
+	defer timer.Stop()
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		log.Printf("[📦] 배치 처리 시작: %d개 메시지", len(batch))
+		err := c.guard(ctx, func(context.Context) error {
+			return handler(batch)
+		})
+		c.settleBatch(batch, err)
+		batch = make([]amqp.Delivery, 0, size)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// 처리하지 못한 메시지는 큐로 되돌린다
+			nackAll(batch, true)
+			return ctx.Err()
+		case <-timer.C:
+			flush()
+		case msg, ok := <-msgs:
+			if !ok {
+				// 취소된 consumer의 미확인 메시지도 채널이 살아 있으면 ACK할 수 있다
+				flush()
+				if !c.endSubscription() {
+					resubscribe, err := c.handleCancel(ctx)
+					if !resubscribe {
+						return err
+					}
+				}
+				if msgs, err = c.subscribe(ctx); err != nil {
+					return err
+				}
+				continue
+			}
+			batch = append(batch, msg)
+			if len(batch) == 1 {
+				timer.Reset(wait)
+			}
+			if len(batch) >= size {
+				flush()
+			}
+		}
+	}
+}
+
+// settleBatch 배치 처리 결과에 따라 ACK/NACK
+// 배치 모드는 전용 채널을 쓰고 이전 배치는 모두 확인된 상태이므로
+// 마지막 메시지의 delivery tag에 multiple=true로 배치 전체를 한 번에 확인한다.
+func (c *Consumer) settleBatch(batch []amqp.Delivery, err error) {
+	var batchErr *BatchError
+	var dlErr *DeadLetterError
+	switch {
+	case err == nil:
+		log.Printf("[✅] 배치 처리 완료: %d개", len(batch))
+		batch[len(batch)-1].Ack(true)
+		return
+	case errors.As(err, &batchErr):
+		// 아래에서 실패한 메시지만 NACK
+	case errors.Is(err, errRequeue):
+		log.Printf("[↩️] 배치 재큐잉: %v", err)
+		nackAll(batch, true)
+		return
+	case errors.As(err, &dlErr):
+		log.Printf("[❌] 배치 처리 실패: %v", err)
+		for _, msg := range batch {
+			c.deadLetter(msg, dlErr)
+		}
+		return
+	default:
+		log.Printf("[❌] 배치 처리 실패: %v", err)
+		nackAll(batch, false)
+		return
+	}
+
+	failed := make([]int, 0, len(batchErr.Failed))
+	for index := range batchErr.Failed {
+		if index >= 0 && index < len(batch) {
+			failed = append(failed, index)
+		}
+	}
+	sort.Ints(failed)
+
+	// 실패한 메시지를 먼저 개별 NACK하면 남은 메시지는 multiple ACK 한 번으로 확인된다
+	isFailed := make(map[int]bool, len(failed))
+	for _, index := range failed {
+		log.Printf("[❌] 배치 메시지 #%d 처리 실패: %v", index, batchErr.Failed[index])
+		batch[index].Nack(false, false)
+		isFailed[index] = true
+	}
+
+	for i := len(batch) - 1; i >= 0; i-- {
+		if !isFailed[i] {
+			batch[i].Ack(true)
+			break
+		}
+	}
+	log.Printf("[✅] 배치 처리 완료: 성공 %d개, 실패 %d개", len(batch)-len(failed), len(failed))
+}
+
+// nackAll 마지막 메시지의 delivery tag에 multiple=true로 배치 전체 NACK
+func nackAll(batch []amqp.Delivery, requeue bool) {
+	if len(batch) == 0 {
+		return
+	}
+	batch[len(batch)-1].Nack(true, requeue)
+}
+
+// batchMode 배치 모드 설정 여부 (전용 채널이 필요한지)
+func (c ConsumerConfig) batchMode() bool {
+	return c.BatchSize > 0 || c.BatchWait > 0
+}
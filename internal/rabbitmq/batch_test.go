@@ -0,0 +1,165 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ackCall 테스트용 Acknowledger가 기록한 ACK/NACK 호출
+type ackCall struct {
+	op       string // ack, nack, reject
+	tag      uint64
+	multiple bool
+	requeue  bool
+}
+
+// recordingAcknowledger 채널 대신 ACK/NACK 호출을 기록
+type recordingAcknowledger struct {
+	mu    sync.Mutex
+	calls []ackCall
+}
+
+func (a *recordingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, ackCall{op: "ack", tag: tag, multiple: multiple})
+	return nil
+}
+
+func (a *recordingAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, ackCall{op: "nack", tag: tag, multiple: multiple, requeue: requeue})
+	return nil
+}
+
+func (a *recordingAcknowledger) Reject(tag uint64, requeue bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, ackCall{op: "reject", tag: tag, requeue: requeue})
+	return nil
+}
+
+// newTestBatch delivery tag가 1부터 n까지인 배치 생성
+func newTestBatch(ack amqp.Acknowledger, n int) []amqp.Delivery {
+	batch := make([]amqp.Delivery, n)
+	for i := range batch {
+		batch[i] = amqp.Delivery{Acknowledger: ack, DeliveryTag: uint64(i + 1)}
+	}
+	return batch
+}
+
+func TestSettleBatch(t *testing.T) {
+	partial := NewBatchError()
+	partial.Add(1, errors.New("bad"))
+	partial.Add(3, errors.New("bad"))
+	partial.Add(7, errors.New("out of range index is ignored"))
+
+	lastFailed := NewBatchError()
+	lastFailed.Add(3, errors.New("bad"))
+
+	allFailed := NewBatchError()
+	for i := 0; i < 4; i++ {
+		allFailed.Add(i, errors.New("bad"))
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want []ackCall
+	}{
+		{
+			name: "success acks the last tag with multiple",
+			err:  nil,
+			want: []ackCall{{op: "ack", tag: 4, multiple: true}},
+		},
+		{
+			name: "requeue nacks the last tag with multiple and requeue",
+			err:  fmt.Errorf("temporary failure: %w", errRequeue),
+			want: []ackCall{{op: "nack", tag: 4, multiple: true, requeue: true}},
+		},
+		{
+			name: "whole batch failure nacks with multiple",
+			err:  errors.New("boom"),
+			want: []ackCall{{op: "nack", tag: 4, multiple: true}},
+		},
+		{
+			name: "partial failure nacks failed messages then acks with multiple",
+			err:  partial,
+			want: []ackCall{
+				{op: "nack", tag: 2},
+				{op: "nack", tag: 4},
+				{op: "ack", tag: 3, multiple: true},
+			},
+		},
+		{
+			name: "failed last message acks up to the last success",
+			err:  lastFailed,
+			want: []ackCall{
+				{op: "nack", tag: 4},
+				{op: "ack", tag: 3, multiple: true},
+			},
+		},
+		{
+			name: "all failed sends no ack",
+			err:  allFailed,
+			want: []ackCall{
+				{op: "nack", tag: 1},
+				{op: "nack", tag: 2},
+				{op: "nack", tag: 3},
+				{op: "nack", tag: 4},
+			},
+		},
+		{
+			name: "dead letter without DLX nacks each message",
+			err:  NewDeadLetterError(errors.New("invalid"), nil),
+			want: []ackCall{
+				{op: "nack", tag: 1},
+				{op: "nack", tag: 2},
+				{op: "nack", tag: 3},
+				{op: "nack", tag: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ack := &recordingAcknowledger{}
+			c := &Consumer{queueName: "test.queue"}
+			c.settleBatch(newTestBatch(ack, 4), tt.err)
+			if !reflect.DeepEqual(ack.calls, tt.want) {
+				t.Errorf("calls = %+v, want %+v", ack.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestNackAllEmpty(t *testing.T) {
+	nackAll(nil, true) // 빈 배치는 아무것도 하지 않아야 한다
+}
+
+func TestConsumeBatchRequiresBatchConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ConsumerConfig
+	}{
+		{"no batch settings", ConsumerConfig{QueueName: "orders"}},
+		{"stream queue", ConsumerConfig{QueueName: "events", QueueType: QueueTypeStream, BatchSize: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Consumer{queueName: tt.config.QueueName, config: tt.config}
+			err := c.ConsumeBatch(context.Background(), func([]amqp.Delivery) error { return nil })
+			if err == nil {
+				t.Fatal("ConsumeBatch() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Binding 큐 바인딩 정의
+// headers exchange는 RoutingKey 대신 Args로 매칭한다 (예: {"x-match": "all", "type": "order"}).
+type Binding struct {
+	Exchange   string
+	RoutingKey string
+	Args       amqp.Table
+}
+
+// BindingLister 큐에 현재 걸려 있는 바인딩 조회
+// AMQP 0-9-1로는 바인딩을 조회할 수 없으므로 Management API 등으로 구현한다.
+type BindingLister func(queueName string) ([]Binding, error)
+
+// bindings 설정된 전체 바인딩 (단일 Exchange/RoutingKey 설정 포함)
+func (c ConsumerConfig) bindings() []Binding {
+	bindings := make([]Binding, 0, len(c.Bindings)+1)
+	if c.Exchange != "" {
+		bindings = append(bindings, Binding{Exchange: c.Exchange, RoutingKey: c.RoutingKey})
+	}
+	return append(bindings, c.Bindings...)
+}
+
+// applyBindings 설정된 바인딩을 생성하고, BindingLister가 있으면 설정에서 빠진 바인딩을 해제
+func applyBindings(ch *amqp.Channel, config ConsumerConfig) error {
+	desired := config.bindings()
+	wanted := make(map[string]bool, len(desired))
+
+	for _, b := range desired {
+		err := ch.QueueBind(
+			config.QueueName,
+			b.RoutingKey,
+			b.Exchange,
+			false,
+			b.Args,
+		)
+		if err != nil {
+			return fmt.Errorf("queue 바인딩 실패 (%s → %s): %w", b.Exchange, b.RoutingKey, err)
+		}
+		wanted[b.key()] = true
+	}
+
+	if config.BindingLister == nil {
+		return nil
+	}
+
+	current, err := config.BindingLister(config.QueueName)
+	if err != nil {
+		// 정리는 부가 기능이므로 소비 자체는 막지 않는다
+		log.Printf("[⚠️] 기존 바인딩 조회 실패, 정리 생략: %v", err)
+		return nil
+	}
+
+	for _, b := range current {
+		if b.Exchange == "" || wanted[b.key()] {
+			continue
+		}
+		err := ch.QueueUnbind(config.QueueName, b.RoutingKey, b.Exchange, b.Args)
+		if err != nil {
+			return fmt.Errorf("queue 바인딩 해제 실패 (%s → %s): %w", b.Exchange, b.RoutingKey, err)
+		}
+		log.Printf("[🧹] 설정에서 제거된 바인딩 해제: %s → %s (routing: %s)", b.Exchange, config.QueueName, b.RoutingKey)
+	}
+	return nil
+}
+
+// key 바인딩 비교용 키
+// Management API는 숫자를 float64로, 중첩 테이블을 map으로 주므로 인자를 JSON으로 정규화해 비교한다.
+// (encoding/json은 map 키를 정렬하고, 5와 5.0을 같은 문자열로 쓴다)
+func (b Binding) key() string {
+	return b.Exchange + "\x00" + b.RoutingKey + "\x00" + normalizeArgs(b.Args)
+}
+
+// normalizeArgs 바인딩 인자를 키 순서와 숫자 타입에 상관없는 문자열로 변환
+func normalizeArgs(args amqp.Table) string {
+	if len(args) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		// JSON으로 표현할 수 없는 값이 있으면 출력 형식으로 비교 (fmt도 map 키를 정렬함)
+		return fmt.Sprintf("%v", map[string]interface{}(args))
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return string(data)
+	}
+	data, _ = json.Marshal(normalized)
+	return string(data)
+}
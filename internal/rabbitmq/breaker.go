@@ -0,0 +1,244 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// 서킷 브레이커 기본값
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+	defaultHalfOpenTrials   = 1
+)
+
+// errCircuitOpen 브레이커가 열려 있어 메시지를 큐로 되돌림
+var errCircuitOpen = fmt.Errorf("%w: 서킷 브레이커 open", errRequeue)
+
+// BreakerState 서킷 브레이커 상태
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // 정상 소비
+	BreakerOpen                         // 소비 중지 (메시지는 큐에 남음)
+	BreakerHalfOpen                     // 시험 메시지로 복구 확인
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// BreakerConfig 핸들러 서킷 브레이커 설정
+type BreakerConfig struct {
+	FailureThreshold int                         // 연속 실패 몇 번에 open할지 (기본 5)
+	OpenTimeout      time.Duration               // open 유지 시간, 이후 half-open (기본 30초)
+	HalfOpenTrials   int                         // half-open에서 연속 성공해야 하는 시험 메시지 수 (기본 1)
+	IsFailure        func(err error) bool        // 실패로 셀 에러 판별 (기본: 모든 에러)
+	OnStateChange    func(from, to BreakerState) // 상태 변경 알림 (별도 goroutine에서 변경 순서대로 호출)
+}
+
+// breakerTarget 브레이커가 제어하는 Consumer 동작
+type breakerTarget interface {
+	trip() error
+	untrip()
+	SetPrefetch(n int) error
+	currentPrefetch() int
+}
+
+// breakerEvent OnStateChange로 전달할 상태 변경
+type breakerEvent struct {
+	from, to BreakerState
+}
+
+// circuitBreaker 연속 실패 시 Consumer를 일시 중지하고, 시험 메시지로 복구를 확인한 뒤 재개
+type circuitBreaker struct {
+	consumer breakerTarget
+	queue    string // 로그용 큐 이름
+	config   BreakerConfig
+
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int  // closed: 연속 실패 수
+	successes int  // half-open: 연속 성공 수
+	prefetch  int  // half-open 진입 전 prefetch (closed 복귀 시 복원)
+	saved     bool // prefetch를 저장했는지 (원래 값이 0일 수 있음)
+	events    []breakerEvent
+	notifying bool // events를 전달하는 goroutine 실행 중
+}
+
+func newCircuitBreaker(c *Consumer, config BreakerConfig) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaultFailureThreshold
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = defaultOpenTimeout
+	}
+	if config.HalfOpenTrials <= 0 {
+		config.HalfOpenTrials = defaultHalfOpenTrials
+	}
+	return &circuitBreaker{consumer: c, queue: c.queueName, config: config}
+}
+
+// allow 메시지를 핸들러로 보내도 되는지 확인 (open이면 false)
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state != BreakerOpen
+}
+
+// record 처리 결과 기록, 이 실패로 브레이커가 열렸으면 true
+// 브레이커를 연 메시지는 dead-letter 대신 큐로 되돌린다.
+func (b *circuitBreaker) record(err error) bool {
+	if errors.Is(err, errRequeue) {
+		return false
+	}
+	failed := err != nil
+	if failed && b.config.IsFailure != nil {
+		failed = b.config.IsFailure(err)
+	}
+
+	b.mu.Lock()
+	switch b.state {
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+			b.mu.Unlock()
+			return false
+		}
+		b.failures++
+		if b.failures < b.config.FailureThreshold {
+			b.mu.Unlock()
+			return false
+		}
+	case BreakerHalfOpen:
+		if !failed {
+			b.successes++
+			if b.successes < b.config.HalfOpenTrials {
+				b.mu.Unlock()
+				return false
+			}
+			b.transition(BreakerClosed)
+			b.mu.Unlock()
+			return false
+		}
+	case BreakerOpen:
+		// open 직전에 이미 처리 중이던 메시지
+		b.mu.Unlock()
+		return failed
+	}
+
+	b.transition(BreakerOpen)
+	b.mu.Unlock()
+	return true
+}
+
+// transition 상태 변경 및 부수 작업 (b.mu를 잡은 상태로 호출)
+func (b *circuitBreaker) transition(to BreakerState) {
+	from := b.state
+	b.state = to
+	b.failures = 0
+	b.successes = 0
+
+	log.Printf("[🔌] 서킷 브레이커 %s → %s (%s 큐)", from, to, b.queue)
+
+	c := b.consumer
+	switch to {
+	case BreakerOpen:
+		// 새 메시지를 받지 않도록 구독을 끊고, 일정 시간 후 시험 단계로
+		go func() {
+			if err := c.trip(); err != nil {
+				log.Printf("[❌] 서킷 브레이커 일시 중지 실패: %v", err)
+			}
+		}()
+		time.AfterFunc(b.config.OpenTimeout, b.halfOpen)
+	case BreakerClosed:
+		// 시험용으로 줄였던 prefetch 복원
+		if !b.saved {
+			break
+		}
+		prefetch := b.prefetch
+		b.saved = false
+		go func() {
+			if err := c.SetPrefetch(prefetch); err != nil {
+				log.Printf("[❌] prefetch 복원 실패: %v", err)
+			}
+		}()
+	}
+
+	b.notify(from, to)
+}
+
+// notify 상태 변경을 OnStateChange로 전달할 순서에 추가 (b.mu를 잡은 상태로 호출)
+// 콜백은 잠금 밖에서 goroutine 하나가 변경 순서대로 호출하므로, 콜백이 느려도 처리가 막히지 않고 순서가 뒤바뀌지 않는다.
+func (b *circuitBreaker) notify(from, to BreakerState) {
+	if b.config.OnStateChange == nil {
+		return
+	}
+	b.events = append(b.events, breakerEvent{from: from, to: to})
+	if !b.notifying {
+		b.notifying = true
+		go b.deliverEvents()
+	}
+}
+
+// deliverEvents 쌓인 상태 변경을 모두 전달하면 종료
+func (b *circuitBreaker) deliverEvents() {
+	for {
+		b.mu.Lock()
+		if len(b.events) == 0 {
+			b.notifying = false
+			b.mu.Unlock()
+			return
+		}
+		event := b.events[0]
+		b.events = b.events[1:]
+		b.mu.Unlock()
+
+		b.config.OnStateChange(event.from, event.to)
+	}
+}
+
+// halfOpen open 유지 시간이 지나면 시험 메시지 수만큼만 받도록 prefetch를 줄이고 재개
+func (b *circuitBreaker) halfOpen() {
+	b.mu.Lock()
+	if b.state != BreakerOpen {
+		b.mu.Unlock()
+		return
+	}
+	if !b.saved {
+		b.prefetch = b.consumer.currentPrefetch()
+		b.saved = true
+	}
+	from := b.state
+	b.state = BreakerHalfOpen
+	b.successes = 0
+	b.notify(from, BreakerHalfOpen)
+	b.mu.Unlock()
+
+	log.Printf("[🔌] 서킷 브레이커 %s → %s (%s 큐)", from, BreakerHalfOpen, b.queue)
+
+	c := b.consumer
+	if err := c.SetPrefetch(b.config.HalfOpenTrials); err != nil {
+		log.Printf("[❌] 시험용 prefetch 설정 실패: %v", err)
+	}
+	c.untrip()
+}
+
+// State 현재 상태
+func (b *circuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
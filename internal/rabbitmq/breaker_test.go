@@ -0,0 +1,244 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBreakerTarget Consumer 대신 브레이커가 요청한 동작을 기록
+type fakeBreakerTarget struct {
+	mu        sync.Mutex
+	prefetch  int
+	tripped   bool
+	trips     int
+	prefetchs []int // SetPrefetch 호출 순서
+}
+
+func (f *fakeBreakerTarget) trip() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tripped = true
+	f.trips++
+	return nil
+}
+
+func (f *fakeBreakerTarget) untrip() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tripped = false
+}
+
+func (f *fakeBreakerTarget) SetPrefetch(n int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prefetch = n
+	f.prefetchs = append(f.prefetchs, n)
+	return nil
+}
+
+func (f *fakeBreakerTarget) currentPrefetch() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.prefetch
+}
+
+// stateRecorder OnStateChange 호출을 순서대로 기록
+type stateRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *stateRecorder) record(from, to BreakerState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, from.String()+"→"+to.String())
+}
+
+// wait n개의 알림이 올 때까지 대기
+func (r *stateRecorder) wait(t *testing.T, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		events := append([]string(nil), r.events...)
+		r.mu.Unlock()
+		if len(events) >= n {
+			return events
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d state changes %v, want %d", len(events), events, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func newTestBreaker(target *fakeBreakerTarget, recorder *stateRecorder, config BreakerConfig) *circuitBreaker {
+	if recorder != nil {
+		config.OnStateChange = recorder.record
+	}
+	b := newCircuitBreaker(&Consumer{queueName: "orders.queue"}, config)
+	b.consumer = target
+	return b
+}
+
+// waitState 상태가 바뀔 때까지 대기 (half-open은 타이머로 바뀜)
+func waitState(t *testing.T, b *circuitBreaker, want BreakerState) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for b.State() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("state = %s, want %s", b.State(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	errBoom := errors.New("boom")
+	errIgnored := errors.New("validation")
+
+	target := &fakeBreakerTarget{prefetch: 10}
+	b := newTestBreaker(target, nil, BreakerConfig{
+		FailureThreshold: 3,
+		OpenTimeout:      time.Hour,
+		IsFailure:        func(err error) bool { return !errors.Is(err, errIgnored) },
+	})
+
+	steps := []struct {
+		err      error
+		wantOpen bool
+	}{
+		{errBoom, false},
+		{errBoom, false},
+		{nil, false}, // 성공하면 연속 실패 수 초기화
+		{errBoom, false},
+		{errBoom, false},
+		{errIgnored, false}, // IsFailure가 false인 에러도 성공처럼 초기화
+		{errBoom, false},
+		{fmt.Errorf("retry: %w", errRequeue), false}, // 재큐잉은 세지도 초기화하지도 않음
+		{errBoom, false},
+		{errBoom, true},
+	}
+	for i, step := range steps {
+		if got := b.record(step.err); got != step.wantOpen {
+			t.Fatalf("step %d: record(%v) = %v, want %v", i, step.err, got, step.wantOpen)
+		}
+	}
+
+	if b.State() != BreakerOpen || b.allow() {
+		t.Fatalf("state = %s, allow = %v, want open and not allowed", b.State(), b.allow())
+	}
+	// open 직전에 처리 중이던 메시지의 실패도 큐로 되돌린다
+	if !b.record(errBoom) {
+		t.Error("record() in open state = false, want true for a failure")
+	}
+	if b.record(nil) {
+		t.Error("record(nil) in open state = true, want false")
+	}
+}
+
+func TestBreakerRecovery(t *testing.T) {
+	target := &fakeBreakerTarget{prefetch: 10}
+	recorder := &stateRecorder{}
+	b := newTestBreaker(target, recorder, BreakerConfig{
+		FailureThreshold: 1,
+		OpenTimeout:      10 * time.Millisecond,
+		HalfOpenTrials:   2,
+	})
+
+	b.record(errors.New("boom"))
+	waitState(t, b, BreakerHalfOpen)
+
+	// half-open에서는 시험 메시지 수만큼만 받는다
+	if b.record(nil) || b.State() != BreakerHalfOpen {
+		t.Fatalf("state after one trial = %s, want half-open", b.State())
+	}
+	b.record(nil)
+	if b.State() != BreakerClosed {
+		t.Fatalf("state after trials = %s, want closed", b.State())
+	}
+
+	want := []string{"closed→open", "open→half-open", "half-open→closed"}
+	if got := recorder.wait(t, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("state changes = %v, want %v", got, want)
+	}
+
+	// 시험용 prefetch로 줄였다가 원래 값으로 복원
+	deadline := time.Now().Add(5 * time.Second)
+	for target.currentPrefetch() != 10 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	if !reflect.DeepEqual(target.prefetchs, []int{2, 10}) || target.tripped || target.trips != 1 {
+		t.Errorf("prefetch calls = %v, tripped = %v, trips = %d", target.prefetchs, target.tripped, target.trips)
+	}
+}
+
+func TestBreakerHalfOpenFailureReopens(t *testing.T) {
+	target := &fakeBreakerTarget{}
+	recorder := &stateRecorder{}
+	b := newTestBreaker(target, recorder, BreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+
+	b.record(errors.New("boom"))
+	waitState(t, b, BreakerHalfOpen)
+	if !b.record(errors.New("still broken")) {
+		t.Fatal("failed trial should reopen the breaker")
+	}
+	waitState(t, b, BreakerHalfOpen)
+
+	want := []string{"closed→open", "open→half-open", "half-open→open", "open→half-open"}
+	if got := recorder.wait(t, 4); !reflect.DeepEqual(got[:4], want) {
+		t.Errorf("state changes = %v, want %v", got, want)
+	}
+}
+
+func TestBreakerStateChangesAreOrdered(t *testing.T) {
+	target := &fakeBreakerTarget{}
+
+	// 콜백이 느려도 변경 순서대로 전달되고 브레이커 처리는 막히지 않는다
+	var mu sync.Mutex
+	var got []string
+	release := make(chan struct{})
+	b := newTestBreaker(target, nil, BreakerConfig{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Hour,
+		OnStateChange: func(from, to BreakerState) {
+			<-release
+			mu.Lock()
+			got = append(got, from.String()+"→"+to.String())
+			mu.Unlock()
+		},
+	})
+
+	const cycles = 20
+	var want []string
+	for i := 0; i < cycles; i++ {
+		b.record(errors.New("boom")) // closed → open
+		b.halfOpen()                 // open → half-open
+		b.record(nil)                // half-open → closed
+		want = append(want, "closed→open", "open→half-open", "half-open→closed")
+	}
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := len(got)
+		mu.Unlock()
+		if n >= len(want) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("state changes out of order:\n got %v\nwant %v", got, want)
+	}
+}
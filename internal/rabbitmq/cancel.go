@@ -0,0 +1,117 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// 취소 후 재선언/재구독 재시도 설정
+const (
+	reconsumeAttempts = 5
+	reconsumeBackoff  = time.Second
+)
+
+// 구독이 끝난 뒤 취소 알림이 기록되기를 기다리는 최대 시간
+const cancelNoticeWait = time.Second
+
+// ErrConsumerCancelled 브로커가 consumer를 취소함 (큐 삭제, quorum 리더 이동 등)
+var ErrConsumerCancelled = errors.New("브로커가 consumer를 취소했습니다")
+
+// CancelEvent 브로커의 consumer 취소 알림
+type CancelEvent struct {
+	Queue       string
+	ConsumerTag string
+	Time        time.Time
+	Recovered   bool  // 재선언 및 재구독 성공 여부
+	Err         error // 복구하지 않았거나 실패한 경우의 원인
+}
+
+// handleCancel 구독 종료 원인 확인 후 재구독 여부 결정
+// 브로커 취소가 아니면 (채널/연결 종료) 조용히 끝낸다.
+func (c *Consumer) handleCancel(ctx context.Context) (bool, error) {
+	if !c.cancelledByBroker() {
+		return false, nil
+	}
+
+	log.Printf("[⚠️] 브로커가 %s 큐의 consumer(%s)를 취소했습니다", c.queueName, c.tag)
+	event := CancelEvent{
+		Queue:       c.queueName,
+		ConsumerTag: c.tag,
+		Time:        time.Now(),
+	}
+
+	if !c.config.ReconsumeOnCancel {
+		event.Err = fmt.Errorf("%w: %s", ErrConsumerCancelled, c.queueName)
+		c.emitCancel(event)
+		return false, event.Err
+	}
+
+	backoff := reconsumeBackoff
+	var err error
+	for attempt := 1; attempt <= reconsumeAttempts; attempt++ {
+		if err = c.declare(); err == nil {
+			log.Printf("[🔄] %s 큐 재선언 완료, 다시 구독합니다", c.queueName)
+			event.Recovered = true
+			c.emitCancel(event)
+			return true, nil
+		}
+		log.Printf("[❌] 큐 재선언 실패 (%d/%d): %v", attempt, reconsumeAttempts, err)
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	event.Err = fmt.Errorf("%w: 재선언 실패: %v", ErrConsumerCancelled, err)
+	c.emitCancel(event)
+	return false, event.Err
+}
+
+// watchCancels 채널의 취소 알림을 계속 비우고, 이 consumer tag에 대한 알림만 cancelled로 전달
+// 비우지 않으면 버퍼가 찬 뒤 라이브러리가 알림 전송에서 막혀 채널 전체가 멈춘다.
+func (c *Consumer) watchCancels(notify <-chan string) {
+	defer close(c.notifyDone)
+	for tag := range notify {
+		if tag != c.tag {
+			continue
+		}
+		select {
+		case c.cancelled <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// cancelledByBroker 구독 종료가 브로커 취소 때문인지 확인
+// 라이브러리는 알림을 보낸 직후 deliveries 채널을 닫으므로 watchCancels가 전달할 때까지 잠시 기다린다.
+// 채널/연결이 닫혀 끝난 구독이면 알림 채널도 닫히므로 기다리지 않는다.
+func (c *Consumer) cancelledByBroker() bool {
+	timer := time.NewTimer(cancelNoticeWait)
+	defer timer.Stop()
+
+	select {
+	case <-c.cancelled:
+		return true
+	case <-c.notifyDone:
+		select {
+		case <-c.cancelled:
+			return true
+		default:
+			return false
+		}
+	case <-timer.C:
+		return false
+	}
+}
+
+func (c *Consumer) emitCancel(event CancelEvent) {
+	if c.config.OnCancel != nil {
+		c.config.OnCancel(event)
+	}
+}
@@ -0,0 +1,66 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newCancelTestConsumer 취소 알림 채널을 watchCancels에 연결한 Consumer
+func newCancelTestConsumer(config ConsumerConfig) (*Consumer, chan string) {
+	c := &Consumer{
+		queueName:  "orders.queue",
+		tag:        "orders.queue.1",
+		config:     config,
+		cancelled:  make(chan struct{}, 1),
+		notifyDone: make(chan struct{}),
+	}
+	notify := make(chan string, 4)
+	go c.watchCancels(notify)
+	return c, notify
+}
+
+func TestCancelledByBroker(t *testing.T) {
+	t.Run("cancel for this consumer tag", func(t *testing.T) {
+		c, notify := newCancelTestConsumer(ConsumerConfig{})
+		notify <- "other.consumer" // 같은 채널의 다른 consumer 알림은 무시
+		notify <- c.tag
+		if !c.cancelledByBroker() {
+			t.Error("cancelledByBroker() = false, want true")
+		}
+		close(notify)
+	})
+
+	t.Run("channel closed without cancel", func(t *testing.T) {
+		c, notify := newCancelTestConsumer(ConsumerConfig{})
+		notify <- "other.consumer"
+		close(notify)
+
+		start := time.Now()
+		if c.cancelledByBroker() {
+			t.Error("cancelledByBroker() = true, want false")
+		}
+		if elapsed := time.Since(start); elapsed >= cancelNoticeWait {
+			t.Errorf("waited %v after the notify channel closed, want no wait", elapsed)
+		}
+	})
+}
+
+func TestHandleCancel(t *testing.T) {
+	var events []CancelEvent
+	c, notify := newCancelTestConsumer(ConsumerConfig{
+		OnCancel: func(event CancelEvent) { events = append(events, event) },
+	})
+	defer close(notify)
+
+	// ReconsumeOnCancel이 없으면 재구독하지 않고 에러로 끝낸다
+	notify <- c.tag
+	retry, err := c.handleCancel(context.Background())
+	if retry || !errors.Is(err, ErrConsumerCancelled) {
+		t.Fatalf("handleCancel() = %v, %v, want false, ErrConsumerCancelled", retry, err)
+	}
+	if len(events) != 1 || events[0].Recovered || events[0].ConsumerTag != c.tag || !errors.Is(events[0].Err, ErrConsumerCancelled) {
+		t.Errorf("OnCancel events = %+v", events)
+	}
+}
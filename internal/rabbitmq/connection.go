@@ -3,6 +3,8 @@ package rabbitmq
 import (
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -19,7 +21,10 @@ func NewConnection(url string) (*Connection, error) {
 
 	// 재시도 로직 (RabbitMQ 시작 대기)
 	for i := 0; i < 5; i++ {
-		conn, err = amqp.Dial(url)
+		conn, err = amqp.DialConfig(url, amqp.Config{
+			Locale:     "en_US",
+			Properties: clientProperties(),
+		})
 		if err == nil {
 			break
 		}
@@ -46,6 +51,16 @@ func (c *Connection) Channel() *amqp.Channel {
 	return c.channel
 }
 
+// NewChannel 기본 채널과 분리된 채널 열기 (닫는 것은 호출한 쪽 책임)
+// confirm 모드처럼 채널 전체에 적용되는 설정이나, 실패하면 채널이 닫히는 작업(passive 선언 등)에 사용한다.
+func (c *Connection) NewChannel() (*amqp.Channel, error) {
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("채널 생성 실패: %w", err)
+	}
+	return ch, nil
+}
+
 func (c *Connection) Close() {
 	if c.channel != nil {
 		c.channel.Close()
@@ -54,3 +69,12 @@ func (c *Connection) Close() {
 		c.conn.Close()
 	}
 }
+
+// clientProperties Management UI에서 어느 프로세스의 연결인지 알 수 있도록 connection_name 지정
+// 예: consumer@orders-7c9f5 (pid 42)
+func clientProperties() amqp.Table {
+	props := amqp.NewConnectionProperties()
+	host, _ := os.Hostname()
+	props.SetClientConnectionName(fmt.Sprintf("%s@%s (pid %d)", filepath.Base(os.Args[0]), host, os.Getpid()))
+	return props
+}
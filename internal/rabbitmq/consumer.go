@@ -1,45 +1,175 @@
 package rabbitmq
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type Consumer struct {
-	conn      *Connection
-	queueName string
+	conn        *Connection
+	channel     *amqp.Channel // 구독, QoS, 취소에 쓰는 채널 (배치 모드면 전용 채널)
+	queueName   string
+	config      ConsumerConfig
+	middlewares []Middleware
+	tag         string         // consumer tag
+	cancelled   chan struct{}  // 브로커가 이 consumer를 취소하면 신호 (watchCancels가 보냄)
+	notifyDone  chan struct{}  // 채널이 닫혀 취소 알림이 더 오지 않으면 닫힘
+	offsets     *offsetTracker // stream 오프셋 완료 추적 (stream 모드)
+	committer   *offsetCommitter
+	breaker     *circuitBreaker
+	abandoned   atomic.Int64 // 시간 초과, 취소로 결과를 기다리지 않았지만 아직 실행 중인 핸들러 수
+
+	// 실행 중 제어 (Pause/Resume/SetPrefetch)
+	mu         sync.Mutex
+	prefetch   int
+	active     bool          // 브로커에 구독 중
+	paused     bool          // 운영자 일시 중지 (Pause/Resume)
+	tripped    bool          // 서킷 브레이커 open으로 중지
+	resumed    chan struct{} // 두 중지 사유가 모두 풀리면 닫힘
+	restarting bool          // SetPrefetch로 재구독 중
 }
 
 type ConsumerConfig struct {
-	QueueName    string
-	Exchange     string
-	RoutingKey   string
-	DLQExchange  string // Dead Letter Exchange
-	DLQQueue     string // Dead Letter Queue
-	MaxRetries   int32  // 최대 재시도 횟수
-	TTL          int32  // 메시지 TTL (밀리초)
-	PrefetchCount int   // Consumer가 한 번에 가져올 메시지 수
+	QueueName      string
+	Exchange       string // 단일 바인딩 (Bindings와 함께 사용 가능)
+	RoutingKey     string
+	DLQExchange    string        // Dead Letter Exchange
+	DLQQueue       string        // Dead Letter Queue
+	MaxRetries     int32         // 최대 재시도 횟수
+	TTL            int32         // 메시지 TTL (밀리초)
+	PrefetchCount  int           // Consumer가 한 번에 가져올 메시지 수
+	HandlerTimeout time.Duration // 메시지당 핸들러 처리 제한 시간 (0 = 제한 없음)
+	BatchSize      int           // 배치 모드: 한 번에 처리할 최대 메시지 수 (설정하면 전용 채널 사용)
+	BatchWait      time.Duration // 배치 모드: 배치가 차지 않아도 처리할 최대 대기 시간
+
+	// 추가 바인딩 (여러 routing key, headers exchange 인자)
+	Bindings      []Binding
+	BindingLister BindingLister // 설정되면 Bindings에 없는 기존 바인딩을 해제
+
+	// 큐 인자 (Validate에서 큐 타입별 조합을 검증)
+	QueueType            QueueType    // x-queue-type: classic(기본), quorum, stream
+	DeliveryLimit        int32        // x-delivery-limit: 재전달 최대 횟수 (quorum 전용)
+	MaxLength            int64        // x-max-length: 최대 메시지 수
+	MaxLengthBytes       int64        // x-max-length-bytes: 최대 메시지 바이트
+	Overflow             OverflowMode // x-overflow: 최대 길이 초과 시 동작
+	MaxPriority          int          // x-max-priority: 우선순위 단계 수 (classic 전용, 1~255)
+	SingleActiveConsumer bool         // x-single-active-consumer
+	Expires              int32        // x-expires: 미사용 큐 자동 삭제 시간 (밀리초)
+	LazyMode             bool         // x-queue-mode=lazy (classic 전용)
+
+	// 키 파티션 병렬 처리 (같은 키는 항상 같은 worker에서 순서대로 처리)
+	Partitions   int     // worker 수 (0 또는 1이면 순차 처리)
+	PartitionKey KeyFunc // 파티션 키 추출 (HeaderKey, BodyFieldKey 등)
+
+	// stream 큐 소비 (QueueType == stream)
+	StreamOffset StreamOffset // 저장된 오프셋이 없을 때 시작 위치 (기본 next)
+	OffsetStore  OffsetStore  // 처리한 오프셋 저장소 (nil이면 재시작 시 StreamOffset부터)
+	ConsumerName string       // consumer tag 겸 오프셋 저장 키 (빈 값이면 자동 생성)
+	// 오프셋은 메시지마다 저장하지 않고 모아서 저장한다 (종료, 재구독 시에는 바로 저장)
+	OffsetCommitEvery    int           // 완료된 메시지 N개마다 저장 (기본 100)
+	OffsetCommitInterval time.Duration // 저장하지 않은 오프셋이 생기고 이 시간이 지나면 저장 (기본 1초)
+
+	// 연속 실패 시 소비를 멈추는 서킷 브레이커 (nil이면 사용 안 함, 배치 모드 미적용)
+	CircuitBreaker *BreakerConfig
+
+	// 브로커의 consumer 취소 (basic.cancel) 처리
+	OnCancel          func(CancelEvent) // 취소 및 복구 결과 알림
+	ReconsumeOnCancel bool              // true면 큐를 재선언하고 다시 구독
 }
 
 func NewConsumer(conn *Connection, config ConsumerConfig) (*Consumer, error) {
-	ch := conn.Channel()
+	// 잘못된 인자 조합은 브로커가 채널을 닫아버리므로 미리 검증
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	// stream 큐는 수동 ACK와 prefetch 설정이 필수
+	if config.queueType() == QueueTypeStream && config.PrefetchCount <= 0 {
+		log.Printf("[ℹ️] stream 큐는 prefetch가 필요하므로 PrefetchCount=%d로 설정합니다", defaultStreamPrefetch)
+		config.PrefetchCount = defaultStreamPrefetch
+	}
+
+	// 오프셋 저장소가 없어도 재구독(재개, 취소 복구) 시 처리한 위치부터 이어받도록 메모리에 기록
+	if config.queueType() == QueueTypeStream && config.OffsetStore == nil {
+		config.OffsetStore = NewMemoryOffsetStore()
+	}
+
+	c := &Consumer{
+		conn:      conn,
+		channel:   conn.Channel(),
+		queueName: config.QueueName,
+		config:    config,
+		tag:       config.ConsumerName,
+		prefetch:  config.PrefetchCount,
+	}
+	if c.tag == "" {
+		c.tag = fmt.Sprintf("%s.%d", config.QueueName, time.Now().UnixNano())
+	}
+	if config.OffsetStore != nil {
+		c.committer = newOffsetCommitter(config.OffsetStore, c.tag, config.OffsetCommitEvery, config.OffsetCommitInterval)
+	}
+
+	if config.CircuitBreaker != nil {
+		c.breaker = newCircuitBreaker(c, *config.CircuitBreaker)
+	}
+
+	// 배치 모드는 multiple=true로 ACK하므로 다른 consumer의 메시지까지 확인하지 않도록 채널을 따로 쓴다
+	if config.batchMode() {
+		ch, err := conn.NewChannel()
+		if err != nil {
+			return nil, err
+		}
+		c.channel = ch
+	}
+
+	if err := c.declare(); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	// 브로커의 basic.cancel 알림 구독 (큐 삭제, quorum 리더 변경 등)
+	// 채널을 공유하는 다른 consumer의 알림도 오므로 별도 goroutine에서 계속 비우며 이 consumer 것만 남긴다
+	c.cancelled = make(chan struct{}, 1)
+	c.notifyDone = make(chan struct{})
+	go c.watchCancels(c.channel.NotifyCancel(make(chan string, 8)))
+
+	return c, nil
+}
+
+// Close 저장하지 않은 stream 오프셋을 저장하고, 전용 채널을 사용 중이면 닫기 (공유 채널은 Connection.Close가 닫는다)
+func (c *Consumer) Close() error {
+	c.flushOffset()
+	if c.channel == c.conn.Channel() {
+		return nil
+	}
+	return c.channel.Close()
+}
+
+// declare Prefetch, DLQ, 메인 큐와 바인딩 선언 (모두 멱등이므로 재선언에도 사용)
+func (c *Consumer) declare() error {
+	ch := c.channel
 
 	// Prefetch 설정 (한 번에 처리할 메시지 수 제한)
-	if config.PrefetchCount > 0 {
-		err := ch.Qos(config.PrefetchCount, 0, false)
+	if prefetch := c.currentPrefetch(); prefetch > 0 {
+		err := ch.Qos(prefetch, 0, false)
 		if err != nil {
-			return nil, fmt.Errorf("QoS 설정 실패: %w", err)
+			return fmt.Errorf("QoS 설정 실패: %w", err)
 		}
 	}
 
 	// DLQ 설정이 있으면 DLQ 먼저 생성
-	args := make(amqp.Table)
-	if config.DLQExchange != "" {
+	args := c.config.queueArgs()
+	if c.config.DLQExchange != "" {
 		// DLQ Exchange 선언
 		err := ch.ExchangeDeclare(
-			config.DLQExchange,
+			c.config.DLQExchange,
 			"direct",
 			true,
 			false,
@@ -48,12 +178,12 @@ func NewConsumer(conn *Connection, config ConsumerConfig) (*Consumer, error) {
 			nil,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("DLQ exchange 선언 실패: %w", err)
+			return fmt.Errorf("DLQ exchange 선언 실패: %w", err)
 		}
 
 		// DLQ Queue 선언
 		_, err = ch.QueueDeclare(
-			config.DLQQueue,
+			c.config.DLQQueue,
 			true,
 			false,
 			false,
@@ -61,34 +191,29 @@ func NewConsumer(conn *Connection, config ConsumerConfig) (*Consumer, error) {
 			nil,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("DLQ queue 선언 실패: %w", err)
+			return fmt.Errorf("DLQ queue 선언 실패: %w", err)
 		}
 
 		// DLQ 바인딩
 		err = ch.QueueBind(
-			config.DLQQueue,
-			config.QueueName, // DLQ routing key = 원본 큐 이름
-			config.DLQExchange,
+			c.config.DLQQueue,
+			c.config.QueueName, // DLQ routing key = 원본 큐 이름
+			c.config.DLQExchange,
 			false,
 			nil,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("DLQ 바인딩 실패: %w", err)
+			return fmt.Errorf("DLQ 바인딩 실패: %w", err)
 		}
 
 		// 원본 큐에 DLQ 설정 추가
-		args["x-dead-letter-exchange"] = config.DLQExchange
-		args["x-dead-letter-routing-key"] = config.QueueName
-	}
-
-	// TTL 설정
-	if config.TTL > 0 {
-		args["x-message-ttl"] = config.TTL
+		args["x-dead-letter-exchange"] = c.config.DLQExchange
+		args["x-dead-letter-routing-key"] = c.config.QueueName
 	}
 
 	// 메인 Queue 선언
 	_, err := ch.QueueDeclare(
-		config.QueueName,
+		c.config.QueueName,
 		true,  // durable
 		false, // delete when unused
 		false, // exclusive
@@ -96,62 +221,174 @@ func NewConsumer(conn *Connection, config ConsumerConfig) (*Consumer, error) {
 		args,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("queue 선언 실패: %w", err)
+		return fmt.Errorf("queue 선언 실패: %w", err)
 	}
 
-	// Exchange에 Queue 바인딩
-	if config.Exchange != "" {
-		err = ch.QueueBind(
-			config.QueueName,
-			config.RoutingKey,
-			config.Exchange,
-			false,
-			nil,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("queue 바인딩 실패: %w", err)
-		}
+	// Exchange에 Queue 바인딩 (설정에서 빠진 바인딩은 해제)
+	if err := applyBindings(ch, c.config); err != nil {
+		return err
 	}
 
-	return &Consumer{
-		conn:      conn,
-		queueName: config.QueueName,
-	}, nil
+	return nil
 }
 
 // MessageHandler 메시지 처리 함수 타입
 type MessageHandler func(delivery amqp.Delivery) error
 
+// ContextHandler context를 받는 메시지 처리 함수 타입
+// HandlerTimeout이 설정되어 있으면 ctx에 메시지별 deadline이 걸려 있다.
+// 제한 시간이 지나면 메시지는 바로 dead-letter 되지만 핸들러 goroutine은 강제로 멈출 수 없으므로,
+// 핸들러는 ctx를 하위 호출(DB, HTTP 등)에 넘기고 ctx.Done() 이후에는 부수 효과 없이 반환해야 한다.
+type ContextHandler func(ctx context.Context, delivery amqp.Delivery) error
+
+// Middleware 핸들러를 감싸 공통 처리(중복 제거, 검증 등)를 추가하는 함수 타입
+type Middleware func(next ContextHandler) ContextHandler
+
+// Use 미들웨어 등록 (먼저 등록한 미들웨어가 가장 바깥에서 실행됨)
+func (c *Consumer) Use(mw ...Middleware) {
+	c.middlewares = append(c.middlewares, mw...)
+}
+
+// chain 등록된 미들웨어로 핸들러 감싸기
+func (c *Consumer) chain(handler ContextHandler) ContextHandler {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+	return handler
+}
+
 // Consume 메시지 소비 시작
 func (c *Consumer) Consume(handler MessageHandler) error {
-	msgs, err := c.conn.Channel().Consume(
+	return c.ConsumeContext(context.Background(), withoutContext(handler))
+}
+
+// ConsumeContext ctx가 취소될 때까지 메시지 소비
+// 브로커가 consumer를 취소하면 ReconsumeOnCancel 설정에 따라 재구독하거나 ErrConsumerCancelled를 반환한다.
+func (c *Consumer) ConsumeContext(ctx context.Context, handler ContextHandler) error {
+	handler = c.chain(handler)
+	defer c.flushOffset()
+	for {
+		msgs, err := c.subscribe(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = c.deliver(ctx, msgs, handler)
+		if c.endSubscription() && err == nil {
+			continue // Pause/SetPrefetch로 끝난 구독 (재개되면 다시 구독)
+		}
+		if err != nil {
+			return err
+		}
+
+		resubscribe, err := c.handleCancel(ctx)
+		if !resubscribe {
+			return err
+		}
+	}
+}
+
+// deliver 구독이 끝날 때까지 메시지 처리
+func (c *Consumer) deliver(ctx context.Context, msgs <-chan amqp.Delivery, handler ContextHandler) error {
+	if c.config.Partitions > 1 {
+		return c.deliverPartitioned(ctx, msgs, handler)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-msgs:
+			if !ok {
+				return nil
+			}
+			c.trackOffset(msg)
+			c.process(ctx, handler, msg)
+		}
+	}
+}
+
+// process 메시지 하나를 처리하고 ACK/NACK 및 stream 오프셋 저장
+func (c *Consumer) process(ctx context.Context, handler ContextHandler, msg amqp.Delivery) {
+	if ctx.Err() != nil {
+		// 종료 중에는 핸들러를 실행하지 않고 큐로 되돌린다
+		c.settle(msg, fmt.Errorf("%w: %v", errRequeue, ctx.Err()))
+		return
+	}
+
+	// 브레이커가 열려 있으면 처리하지 않고 큐에 남긴다
+	if c.breaker != nil && !c.breaker.allow() {
+		c.settle(msg, errCircuitOpen)
+		return
+	}
+
+	log.Printf("[📩] 메시지 수신: %s", string(msg.Body))
+	err := c.invoke(ctx, handler, msg)
+	if c.breaker != nil && c.breaker.record(err) {
+		err = fmt.Errorf("%w: %v", errCircuitOpen, err)
+	}
+	c.settle(msg, err)
+	if !errors.Is(err, errRequeue) {
+		c.commitOffset(msg)
+	}
+}
+
+// subscribe 큐 구독 시작 (일시 중지 상태면 재개될 때까지 대기)
+func (c *Consumer) subscribe(ctx context.Context) (<-chan amqp.Delivery, error) {
+	if err := c.waitResume(ctx); err != nil {
+		return nil, err
+	}
+	defer c.mu.Unlock()
+
+	// 이전 구독에 대해 처리되지 않은 취소 알림은 버린다
+	select {
+	case <-c.cancelled:
+	default:
+	}
+
+	var args amqp.Table
+	if c.isStream() {
+		var err error
+		if args, err = c.streamArgs(); err != nil {
+			return nil, err
+		}
+		c.offsets = newOffsetTracker()
+	}
+
+	msgs, err := c.channel.Consume(
 		c.queueName,
-		"",    // consumer tag
-		false, // auto-ack (false = 수동 ACK)
+		c.tag,
+		false, // auto-ack (false = 수동 ACK, stream 큐는 필수)
 		false, // exclusive
 		false, // no-local
 		false, // no-wait
-		nil,
+		args,
 	)
 	if err != nil {
-		return fmt.Errorf("consume 시작 실패: %w", err)
+		return nil, fmt.Errorf("consume 시작 실패: %w", err)
 	}
 
+	c.active = true
 	log.Printf("[*] %s 큐에서 메시지 대기 중...", c.queueName)
+	return msgs, nil
+}
 
-	for msg := range msgs {
-		log.Printf("[📩] 메시지 수신: %s", string(msg.Body))
-
-		err := handler(msg)
-		if err != nil {
-			log.Printf("[❌] 메시지 처리 실패: %v", err)
-			// NACK - 메시지를 DLQ로 보냄 (requeue=false)
-			msg.Nack(false, false)
-		} else {
-			log.Printf("[✅] 메시지 처리 완료")
-			msg.Ack(false)
-		}
+// settle 핸들러 결과에 따라 ACK/NACK 처리
+func (c *Consumer) settle(msg amqp.Delivery, err error) {
+	var dlErr *DeadLetterError
+	switch {
+	case err == nil:
+		log.Printf("[✅] 메시지 처리 완료")
+		msg.Ack(false)
+	case errors.Is(err, errRequeue):
+		log.Printf("[↩️] 메시지 재큐잉: %v", err)
+		msg.Nack(false, true)
+	case errors.As(err, &dlErr):
+		log.Printf("[❌] 메시지 처리 실패: %v", err)
+		c.deadLetter(msg, dlErr)
+	default:
+		log.Printf("[❌] 메시지 처리 실패: %v", err)
+		// NACK - 메시지를 DLQ로 보냄 (requeue=false)
+		msg.Nack(false, false)
 	}
-
-	return nil
 }
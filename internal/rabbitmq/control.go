@@ -0,0 +1,205 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// ConsumerStatus 실행 중인 Consumer 상태
+type ConsumerStatus struct {
+	Queue       string `json:"queue"`
+	ConsumerTag string `json:"consumer_tag"`
+	Active      bool   `json:"active"` // 브로커에 구독 중인지
+	Paused      bool   `json:"paused"`
+	Prefetch    int    `json:"prefetch"`
+	Breaker     string `json:"breaker,omitempty"` // 서킷 브레이커 상태
+	// 시간 초과로 포기했지만 아직 끝나지 않은 핸들러 goroutine 수 (계속 늘어나면 핸들러가 ctx를 무시하는 것)
+	AbandonedHandlers int64 `json:"abandoned_handlers,omitempty"`
+}
+
+// Pause 메시지 소비 일시 중지
+// consumer를 취소(basic.cancel)해 새 메시지를 받지 않으며, 이미 받은 메시지는 끝까지 처리한다.
+// 메시지는 큐에 그대로 남는다.
+func (c *Consumer) Pause() error {
+	c.mu.Lock()
+	if c.paused {
+		c.mu.Unlock()
+		return nil
+	}
+	cancel := c.suspend(&c.paused)
+	c.mu.Unlock()
+
+	log.Printf("[⏸️] %s 큐 소비 일시 중지", c.queueName)
+	return c.cancelSubscription(cancel)
+}
+
+// Resume 일시 중지된 소비 재개 (같은 consumer tag로 다시 구독)
+// 서킷 브레이커가 open이면 half-open으로 바뀔 때 재개된다.
+func (c *Consumer) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.paused {
+		return
+	}
+	if !c.unsuspend(&c.paused) {
+		log.Printf("[⏸️] %s 큐 서킷 브레이커가 open 상태라 half-open 이후 재개", c.queueName)
+		return
+	}
+	log.Printf("[▶️] %s 큐 소비 재개", c.queueName)
+}
+
+// trip 서킷 브레이커가 열려 소비 중지 (운영자의 Pause와 별도로 관리)
+func (c *Consumer) trip() error {
+	c.mu.Lock()
+	if c.tripped {
+		c.mu.Unlock()
+		return nil
+	}
+	cancel := c.suspend(&c.tripped)
+	c.mu.Unlock()
+
+	return c.cancelSubscription(cancel)
+}
+
+// untrip 브레이커 중지 해제 (운영자가 Pause한 상태면 계속 멈춰 있음)
+func (c *Consumer) untrip() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.tripped {
+		return
+	}
+	if !c.unsuspend(&c.tripped) {
+		log.Printf("[⏸️] %s 큐 일시 중지 상태라 Resume 전까지 재개하지 않음", c.queueName)
+	}
+}
+
+// suspend 중지 사유 플래그 설정, 구독을 취소해야 하면 true (c.mu를 잡은 상태로 호출)
+func (c *Consumer) suspend(flag *bool) bool {
+	wasSuspended := c.suspended()
+	*flag = true
+	if wasSuspended {
+		return false
+	}
+	c.resumed = make(chan struct{})
+	return c.active
+}
+
+// unsuspend 중지 사유 플래그 해제, 모든 사유가 풀려 재개되면 true (c.mu를 잡은 상태로 호출)
+func (c *Consumer) unsuspend(flag *bool) bool {
+	*flag = false
+	if c.suspended() {
+		return false
+	}
+	close(c.resumed)
+	return true
+}
+
+// suspended 운영자 일시 중지나 브레이커로 소비가 멈춰 있는지 (c.mu를 잡은 상태로 호출)
+func (c *Consumer) suspended() bool {
+	return c.paused || c.tripped
+}
+
+// cancelSubscription 구독 중이면 consumer 취소
+func (c *Consumer) cancelSubscription(cancel bool) error {
+	if !cancel {
+		return nil
+	}
+	if err := c.channel.Cancel(c.tag, false); err != nil {
+		return fmt.Errorf("consumer 취소 실패: %w", err)
+	}
+	return nil
+}
+
+// SetPrefetch 실행 중 prefetch 변경
+// basic.qos는 이후에 시작한 consumer에만 적용되므로 구독 중이면 재구독한다.
+func (c *Consumer) SetPrefetch(n int) error {
+	if n < 0 {
+		return fmt.Errorf("prefetch는 0 이상이어야 합니다: %d", n)
+	}
+	if n == 0 && c.isStream() {
+		return errors.New("stream 큐는 prefetch가 필요합니다")
+	}
+
+	if err := c.channel.Qos(n, 0, false); err != nil {
+		return fmt.Errorf("QoS 설정 실패: %w", err)
+	}
+
+	c.mu.Lock()
+	c.prefetch = n
+	restart := c.active && !c.suspended()
+	if restart {
+		c.restarting = true
+	}
+	c.mu.Unlock()
+
+	log.Printf("[⚙️] %s 큐 prefetch 변경: %d", c.queueName, n)
+	if !restart {
+		return nil
+	}
+	if err := c.channel.Cancel(c.tag, false); err != nil {
+		return fmt.Errorf("consumer 재구독 실패: %w", err)
+	}
+	return nil
+}
+
+// Status 현재 상태 조회
+func (c *Consumer) Status() ConsumerStatus {
+	var breaker string
+	if c.breaker != nil {
+		breaker = c.breaker.State().String()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return ConsumerStatus{
+		Queue:       c.queueName,
+		ConsumerTag: c.tag,
+		Active:      c.active,
+		Paused:      c.paused,
+		Prefetch:    c.prefetch,
+		Breaker:     breaker,
+
+		AbandonedHandlers: c.abandoned.Load(),
+	}
+}
+
+// currentPrefetch 현재 prefetch 값
+func (c *Consumer) currentPrefetch() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.prefetch
+}
+
+// waitResume 일시 중지 상태면 재개될 때까지 대기 (c.mu를 잡은 상태로 반환)
+func (c *Consumer) waitResume(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+		if !c.suspended() {
+			return nil
+		}
+		resumed := c.resumed
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-resumed:
+		}
+	}
+}
+
+// endSubscription 구독 종료 처리, Pause/브레이커/SetPrefetch로 직접 끝낸 것이면 true
+func (c *Consumer) endSubscription() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.active = false
+	intentional := c.suspended() || c.restarting
+	c.restarting = false
+	return intentional
+}
@@ -0,0 +1,122 @@
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newControlTestConsumer 구독하지 않은 Consumer (채널 호출 없이 상태 전환만 확인)
+func newControlTestConsumer() *Consumer {
+	return &Consumer{queueName: "orders.queue", tag: "orders.queue.1", prefetch: 10}
+}
+
+// resumesWithin waitResume이 d 안에 반환되는지
+func resumesWithin(c *Consumer, d time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	if err := c.waitResume(ctx); err != nil {
+		return false
+	}
+	c.mu.Unlock()
+	return true
+}
+
+func TestPauseResume(t *testing.T) {
+	c := newControlTestConsumer()
+	if !resumesWithin(c, 10*time.Millisecond) {
+		t.Fatal("waitResume blocked on a running consumer")
+	}
+
+	if err := c.Pause(); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if err := c.Pause(); err != nil { // 이미 멈춘 상태면 아무 일도 하지 않는다
+		t.Fatalf("second Pause() error = %v", err)
+	}
+	if !c.Status().Paused || resumesWithin(c, 10*time.Millisecond) {
+		t.Fatal("paused consumer did not block waitResume")
+	}
+
+	// 재개를 기다리던 구독이 Resume으로 풀린다
+	done := make(chan bool)
+	go func() { done <- resumesWithin(c, 5*time.Second) }()
+	c.Resume()
+	if !<-done {
+		t.Error("waitResume did not return after Resume")
+	}
+	if c.Status().Paused {
+		t.Error("Status().Paused = true after Resume")
+	}
+	c.Resume() // 멈추지 않은 상태에서 Resume해도 안전
+}
+
+func TestPauseAndBreakerTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []func(c *Consumer)
+	}{
+		{"resume while breaker is open", []func(c *Consumer){
+			func(c *Consumer) { c.Pause() },
+			func(c *Consumer) { c.trip() },
+			func(c *Consumer) { c.Resume() },
+		}},
+		{"breaker recovers while paused", []func(c *Consumer){
+			func(c *Consumer) { c.trip() },
+			func(c *Consumer) { c.Pause() },
+			func(c *Consumer) { c.untrip() },
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newControlTestConsumer()
+			for _, step := range tt.steps {
+				step(c)
+			}
+			// 두 중지 사유 중 하나가 남아 있으면 재개하지 않는다
+			if resumesWithin(c, 10*time.Millisecond) {
+				t.Fatal("consumer resumed while still suspended")
+			}
+			c.Resume()
+			c.untrip()
+			if !resumesWithin(c, time.Second) {
+				t.Error("consumer did not resume after both causes were cleared")
+			}
+		})
+	}
+}
+
+func TestSetPrefetchValidation(t *testing.T) {
+	c := newControlTestConsumer()
+	if err := c.SetPrefetch(-1); err == nil {
+		t.Error("SetPrefetch(-1) error = nil")
+	}
+
+	c.config.QueueType = QueueTypeStream
+	if err := c.SetPrefetch(0); err == nil {
+		t.Error("SetPrefetch(0) on a stream queue error = nil")
+	}
+	if got := c.Status().Prefetch; got != 10 {
+		t.Errorf("Prefetch = %d after rejected changes, want 10", got)
+	}
+}
+
+func TestEndSubscription(t *testing.T) {
+	c := newControlTestConsumer()
+	c.active = true
+	if c.endSubscription() || c.Status().Active {
+		t.Error("unexpected end should not be intentional")
+	}
+
+	c.Pause()
+	if !c.endSubscription() {
+		t.Error("end after Pause should be intentional")
+	}
+	c.Resume()
+
+	c.restarting = true
+	if !c.endSubscription() || c.restarting {
+		t.Error("end after SetPrefetch restart should be intentional once")
+	}
+}
@@ -0,0 +1,211 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// HeaderDeath 브로커가 dead-letter 시 남기는 헤더
+const HeaderDeath = "x-death"
+
+// DeathInfo x-death 헤더 항목 (큐와 사유별로 하나씩 쌓임)
+type DeathInfo struct {
+	Count              int64     `json:"count"`                         // 같은 큐/사유로 dead-letter 된 횟수
+	Reason             string    `json:"reason"`                        // rejected, expired, maxlen, delivery_limit
+	Queue              string    `json:"queue"`                         // dead-letter 되기 전 큐
+	Exchange           string    `json:"exchange"`                      // 원래 발행된 exchange
+	RoutingKeys        []string  `json:"routing_keys"`                  // 원래 routing key (CC 포함)
+	Time               time.Time `json:"time"`                          // dead-letter 된 시각
+	OriginalExpiration string    `json:"original_expiration,omitempty"` // 메시지 TTL (dead-letter 시 브로커가 제거)
+}
+
+// ParseDeaths x-death 헤더 파싱 (가장 최근 항목이 먼저)
+// 헤더가 없으면 nil을 반환한다. 값 타입은 AMQP 디코딩 결과뿐 아니라 JSON 등 다른 경로로
+// 만들어진 헤더(숫자 문자열, float64, RFC3339 시각 등)도 허용한다.
+func ParseDeaths(headers amqp.Table) ([]DeathInfo, error) {
+	raw, ok := headers[HeaderDeath]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	var entries []interface{}
+	switch v := raw.(type) {
+	case []interface{}:
+		entries = v
+	case []amqp.Table:
+		for _, t := range v {
+			entries = append(entries, t)
+		}
+	case []map[string]interface{}:
+		for _, t := range v {
+			entries = append(entries, t)
+		}
+	default:
+		return nil, fmt.Errorf("x-death 헤더 형식 오류: %T", raw)
+	}
+
+	deaths := make([]DeathInfo, 0, len(entries))
+	for i, entry := range entries {
+		death, err := parseDeath(entry)
+		if err != nil {
+			return nil, fmt.Errorf("x-death[%d] 파싱 실패: %w", i, err)
+		}
+		deaths = append(deaths, death)
+	}
+	return deaths, nil
+}
+
+// LatestDeath 가장 최근 x-death 항목
+func LatestDeath(headers amqp.Table) (DeathInfo, bool, error) {
+	deaths, err := ParseDeaths(headers)
+	if err != nil || len(deaths) == 0 {
+		return DeathInfo{}, false, err
+	}
+	return deaths[0], true, nil
+}
+
+func parseDeath(entry interface{}) (DeathInfo, error) {
+	var table map[string]interface{}
+	switch v := entry.(type) {
+	case amqp.Table:
+		table = v
+	case map[string]interface{}:
+		table = v
+	default:
+		return DeathInfo{}, fmt.Errorf("항목 형식 오류: %T", entry)
+	}
+
+	var death DeathInfo
+	var err error
+	if v, ok := table["count"]; ok {
+		if death.Count, err = toInt64(v); err != nil {
+			return DeathInfo{}, fmt.Errorf("count: %w", err)
+		}
+	}
+	if death.Reason, err = toString(table["reason"]); err != nil {
+		return DeathInfo{}, fmt.Errorf("reason: %w", err)
+	}
+	if death.Queue, err = toString(table["queue"]); err != nil {
+		return DeathInfo{}, fmt.Errorf("queue: %w", err)
+	}
+	if death.Exchange, err = toString(table["exchange"]); err != nil {
+		return DeathInfo{}, fmt.Errorf("exchange: %w", err)
+	}
+	if death.RoutingKeys, err = toStrings(table["routing-keys"]); err != nil {
+		return DeathInfo{}, fmt.Errorf("routing-keys: %w", err)
+	}
+	if v, ok := table["time"]; ok {
+		if death.Time, err = toTime(v); err != nil {
+			return DeathInfo{}, fmt.Errorf("time: %w", err)
+		}
+	}
+	if v, ok := table["original-expiration"]; ok {
+		// 브로커는 문자열로 넣지만 다른 경로로는 숫자(ms)일 수 있다
+		if s, err := toString(v); err == nil {
+			death.OriginalExpiration = s
+		} else if n, err := toInt64(v); err == nil {
+			death.OriginalExpiration = strconv.FormatInt(n, 10)
+		} else {
+			return DeathInfo{}, fmt.Errorf("original-expiration: %v (%T)", v, v)
+		}
+	}
+	return death, nil
+}
+
+// toInt64 AMQP 헤더 값을 int64로 변환 (발행 측에 따라 정수 타입이 다를 수 있음)
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float32:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	default:
+		return 0, fmt.Errorf("정수로 변환할 수 없는 값: %v (%T)", value, value)
+	}
+}
+
+// toString 문자열 헤더 값 (없으면 빈 문자열, 바이트 배열도 허용)
+func toString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("문자열이 아닌 값: %v (%T)", value, value)
+	}
+}
+
+// toStrings 문자열 배열 헤더 값 (단일 문자열도 허용)
+func toStrings(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return v, nil
+	case string, []byte:
+		s, _ := toString(v)
+		return []string{s}, nil
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := toString(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, s)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("문자열 배열이 아닌 값: %v (%T)", value, value)
+	}
+}
+
+// toTime 시각 헤더 값 (AMQP timestamp, Unix 초, RFC3339 문자열)
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+	}
+	sec, err := toInt64(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("시각으로 변환할 수 없는 값: %v (%T)", value, value)
+	}
+	return time.Unix(sec, 0), nil
+}
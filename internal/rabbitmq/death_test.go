@@ -0,0 +1,123 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestParseDeaths(t *testing.T) {
+	deadAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	want := []DeathInfo{{
+		Count:              2,
+		Reason:             "expired",
+		Queue:              "orders.queue",
+		Exchange:           "orders",
+		RoutingKeys:        []string{"order.created", "audit.order"},
+		Time:               deadAt,
+		OriginalExpiration: "60000",
+	}}
+
+	tests := []struct {
+		name    string
+		headers amqp.Table
+		want    []DeathInfo
+	}{
+		{"no header", amqp.Table{}, nil},
+		{"nil header", amqp.Table{HeaderDeath: nil}, nil},
+		{
+			name: "as decoded by amqp091",
+			headers: amqp.Table{HeaderDeath: []interface{}{amqp.Table{
+				"count": int64(2), "reason": "expired", "queue": "orders.queue", "exchange": "orders",
+				"routing-keys": []interface{}{"order.created", "audit.order"},
+				"time":         deadAt, "original-expiration": "60000",
+			}}},
+			want: want,
+		},
+		{
+			name: "table slice with other integer and byte types",
+			headers: amqp.Table{HeaderDeath: []amqp.Table{{
+				"count": int32(2), "reason": []byte("expired"), "queue": "orders.queue", "exchange": "orders",
+				"routing-keys": []string{"order.created", "audit.order"},
+				"time":         deadAt.Unix(), "original-expiration": int64(60000),
+			}}},
+			want: want,
+		},
+		{
+			name: "from JSON",
+			headers: amqp.Table{HeaderDeath: []map[string]interface{}{{
+				"count": float64(2), "reason": "expired", "queue": "orders.queue", "exchange": "orders",
+				"routing-keys": []interface{}{"order.created", "audit.order"},
+				"time":         "2026-10-01T12:00:00Z", "original-expiration": json.Number("60000"),
+			}}},
+			want: want,
+		},
+		{
+			name: "single routing key string and numeric string count",
+			headers: amqp.Table{HeaderDeath: []interface{}{map[string]interface{}{
+				"count": "1", "reason": "rejected", "queue": "orders.queue", "routing-keys": "order.created",
+			}}},
+			want: []DeathInfo{{Count: 1, Reason: "rejected", Queue: "orders.queue", RoutingKeys: []string{"order.created"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDeaths(tt.headers)
+			if err != nil {
+				t.Fatalf("ParseDeaths() error = %v", err)
+			}
+			for i := range got {
+				got[i].Time = got[i].Time.UTC()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDeaths() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDeathsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers amqp.Table
+	}{
+		{"not a list", amqp.Table{HeaderDeath: "rejected"}},
+		{"entry not a table", amqp.Table{HeaderDeath: []interface{}{"rejected"}}},
+		{"bad count", amqp.Table{HeaderDeath: []interface{}{amqp.Table{"count": "many"}}}},
+		{"bad reason", amqp.Table{HeaderDeath: []interface{}{amqp.Table{"reason": 3}}}},
+		{"bad routing keys", amqp.Table{HeaderDeath: []interface{}{amqp.Table{"routing-keys": 3}}}},
+		{"bad time", amqp.Table{HeaderDeath: []interface{}{amqp.Table{"time": true}}}},
+		{"bad expiration", amqp.Table{HeaderDeath: []interface{}{amqp.Table{"original-expiration": true}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseDeaths(tt.headers); err == nil {
+				t.Error("ParseDeaths() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestLatestDeath(t *testing.T) {
+	headers := amqp.Table{HeaderDeath: []interface{}{
+		amqp.Table{"count": int64(1), "queue": "orders.retry", "reason": "expired"},
+		amqp.Table{"count": int64(3), "queue": "orders.queue", "reason": "rejected"},
+	}}
+
+	death, ok, err := LatestDeath(headers)
+	if err != nil || !ok {
+		t.Fatalf("LatestDeath() = %v, %v", ok, err)
+	}
+	if death.Queue != "orders.retry" || death.Reason != "expired" {
+		t.Errorf("LatestDeath() = %+v, want the first entry", death)
+	}
+
+	if _, ok, err := LatestDeath(amqp.Table{}); ok || err != nil {
+		t.Errorf("LatestDeath(no header) = %v, %v, want false, nil", ok, err)
+	}
+}
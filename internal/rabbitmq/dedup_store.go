@@ -0,0 +1,207 @@
+package rabbitmq
+
+import (
+	"container/list"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ============================================
+// 메모리 LRU 저장소
+// ============================================
+
+// MemoryDedupStore 용량 제한이 있는 메모리 LRU 중복 저장소
+// 프로세스 재시작 시 기록이 사라지므로 단일 인스턴스 개발용으로 적합하다.
+type MemoryDedupStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // 앞쪽이 최근 사용
+	entries  map[string]*list.Element
+}
+
+type dedupEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// NewMemoryDedupStore 최대 capacity개의 키를 유지하는 LRU 저장소 생성
+func NewMemoryDedupStore(capacity int) *MemoryDedupStore {
+	if capacity <= 0 {
+		capacity = 10000
+	}
+	return &MemoryDedupStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Seen 처리 기록 확인
+func (s *MemoryDedupStore) Seen(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return false, nil
+	}
+	entry := elem.Value.(*dedupEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		s.order.Remove(elem)
+		delete(s.entries, key)
+		return false, nil
+	}
+	s.order.MoveToFront(elem)
+	return true, nil
+}
+
+// Mark 처리 완료 기록 (ttl <= 0이면 만료 없음)
+func (s *MemoryDedupStore) Mark(key string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if elem, ok := s.entries[key]; ok {
+		elem.Value.(*dedupEntry).expiresAt = expiresAt
+		s.order.MoveToFront(elem)
+		return nil
+	}
+
+	s.entries[key] = s.order.PushFront(&dedupEntry{key: key, expiresAt: expiresAt})
+
+	// 용량 초과 시 가장 오래 사용되지 않은 키 제거
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*dedupEntry).key)
+	}
+	return nil
+}
+
+// Len 저장된 키 수
+func (s *MemoryDedupStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+func (s *MemoryDedupStore) Close() error {
+	return nil
+}
+
+// ============================================
+// bbolt 파일 저장소
+// ============================================
+
+var dedupBucket = []byte("processed")
+
+// BoltDedupStore bbolt 임베디드 파일 기반 중복 저장소
+// 프로세스 재시작 후에도 처리 기록이 유지된다.
+type BoltDedupStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltDedupStore 파일 저장소 열기 (없으면 생성) 후 만료된 키 정리
+func OpenBoltDedupStore(path string) (*BoltDedupStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("중복 저장소 열기 실패: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(dedupBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("중복 저장소 초기화 실패: %w", err)
+	}
+
+	store := &BoltDedupStore{db: db}
+	if _, err := store.PurgeExpired(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Seen 처리 기록 확인
+func (s *BoltDedupStore) Seen(key string) (bool, error) {
+	var seen bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(dedupBucket).Get([]byte(key))
+		if value == nil {
+			return nil
+		}
+		seen = !isExpired(value, time.Now())
+		return nil
+	})
+	return seen, err
+}
+
+// Mark 처리 완료 기록 (ttl <= 0이면 만료 없음)
+func (s *BoltDedupStore) Mark(key string, ttl time.Duration) error {
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(expiresAt))
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(dedupBucket).Put([]byte(key), value)
+	})
+}
+
+// PurgeExpired 만료된 키 삭제, 삭제된 키 수 반환
+func (s *BoltDedupStore) PurgeExpired() (int, error) {
+	now := time.Now()
+	purged := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(dedupBucket)
+
+		// 순회 중 삭제하면 커서가 건너뛸 수 있으므로 키를 먼저 모은다
+		var expired [][]byte
+		err := bucket.ForEach(func(k, v []byte) error {
+			if isExpired(v, now) {
+				expired = append(expired, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range expired {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		purged = len(expired)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("만료 키 정리 실패: %w", err)
+	}
+	return purged, nil
+}
+
+func (s *BoltDedupStore) Close() error {
+	return s.db.Close()
+}
+
+// isExpired 저장된 만료 시각(UnixNano, 0 = 만료 없음) 확인
+func isExpired(value []byte, now time.Time) bool {
+	if len(value) != 8 {
+		return false
+	}
+	expiresAt := int64(binary.BigEndian.Uint64(value))
+	return expiresAt != 0 && now.UnixNano() > expiresAt
+}
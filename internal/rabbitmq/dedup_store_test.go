@@ -0,0 +1,107 @@
+package rabbitmq
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testDedupStore 두 저장소에 공통으로 기대하는 동작
+func testDedupStore(t *testing.T, store DedupStore) {
+	t.Helper()
+
+	seen := func(key string) bool {
+		t.Helper()
+		ok, err := store.Seen(key)
+		if err != nil {
+			t.Fatalf("Seen(%q) error = %v", key, err)
+		}
+		return ok
+	}
+	mark := func(key string, ttl time.Duration) {
+		t.Helper()
+		if err := store.Mark(key, ttl); err != nil {
+			t.Fatalf("Mark(%q) error = %v", key, err)
+		}
+	}
+
+	if seen("order-1") {
+		t.Error("Seen() before Mark = true")
+	}
+	mark("order-1", 0)
+	if !seen("order-1") {
+		t.Error("Seen() after Mark without TTL = false")
+	}
+
+	mark("order-2", 10*time.Millisecond)
+	if !seen("order-2") {
+		t.Error("Seen() before TTL = false")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if seen("order-2") {
+		t.Error("Seen() after TTL = true")
+	}
+
+	// 다시 기록하면 만료 시각이 새로 정해진다
+	mark("order-3", 10*time.Millisecond)
+	mark("order-3", time.Hour)
+	time.Sleep(20 * time.Millisecond)
+	if !seen("order-3") {
+		t.Error("Seen() after re-Mark with longer TTL = false")
+	}
+}
+
+func TestMemoryDedupStore(t *testing.T) {
+	testDedupStore(t, NewMemoryDedupStore(10))
+}
+
+func TestMemoryDedupStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewMemoryDedupStore(2)
+	store.Mark("a", 0)
+	store.Mark("b", 0)
+	store.Seen("a") // a를 최근 사용으로
+	store.Mark("c", 0)
+
+	if store.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", store.Len())
+	}
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if got, _ := store.Seen(key); got != want {
+			t.Errorf("Seen(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestBoltDedupStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedup.db")
+	store, err := OpenBoltDedupStore(path)
+	if err != nil {
+		t.Fatalf("OpenBoltDedupStore() error = %v", err)
+	}
+	testDedupStore(t, store)
+
+	store.Mark("expiring", 10*time.Millisecond)
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// 다시 열어도 기록이 남아 있고, 열 때 만료된 키는 정리된다
+	reopened, err := OpenBoltDedupStore(path)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	defer reopened.Close()
+	if seen, _ := reopened.Seen("order-1"); !seen {
+		t.Error("Seen() after reopen = false, want the record to persist")
+	}
+	if purged, err := reopened.PurgeExpired(); err != nil || purged != 0 {
+		t.Errorf("PurgeExpired() after open = %d, %v, want 0 (already purged on open)", purged, err)
+	}
+
+	reopened.Mark("short", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if purged, err := reopened.PurgeExpired(); err != nil || purged != 1 {
+		t.Errorf("PurgeExpired() = %d, %v, want 1", purged, err)
+	}
+}
@@ -0,0 +1,174 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"rabbit-mq-with-go/internal/alert"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// 알림 샘플 메시지 ID 기본 개수
+const defaultAlertSamples = 5
+
+// DeadLetterRule dead letter 집계 알림 규칙
+// 예: orders.queue에서 5분 안에 10건을 넘으면 알림 한 번
+type DeadLetterRule struct {
+	Name      string         // 규칙 이름 (알림 중복 제거 키)
+	Queue     string         // dead-letter 되기 전 큐 (빈 값이면 전체)
+	Reason    string         // dead-letter 사유 (빈 값이면 전체)
+	Threshold int            // Window 안의 건수가 이 값을 넘으면 알림
+	Window    time.Duration  // 집계 구간
+	Cooldown  time.Duration  // 같은 규칙 재알림 최소 간격 (기본 Window)
+	Samples   int            // 알림에 담을 샘플 메시지 ID 수 (기본 5)
+	Severity  alert.Severity // 기본 warning
+}
+
+// DeadLetterAlerter dead letter를 규칙별로 집계해 알림을 보낸다
+// 임계값을 넘는 동안 메시지마다 알림을 보내지 않고, Cooldown마다 한 번만 보낸다.
+type DeadLetterAlerter struct {
+	sink  alert.Sink
+	rules []*ruleState
+}
+
+// ruleState 규칙별 최근 dead letter 기록
+type ruleState struct {
+	rule      DeadLetterRule
+	mu        sync.Mutex
+	events    []ruleEvent
+	lastFired time.Time
+}
+
+type ruleEvent struct {
+	at        time.Time
+	messageID string
+}
+
+// NewDeadLetterAlerter 규칙 검증 후 Alerter 생성
+func NewDeadLetterAlerter(sink alert.Sink, rules ...DeadLetterRule) (*DeadLetterAlerter, error) {
+	if sink == nil {
+		return nil, errors.New("알림 Sink가 필요합니다")
+	}
+
+	a := &DeadLetterAlerter{sink: sink}
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("규칙 %d: 이름이 필요합니다", i)
+		}
+		if rule.Threshold < 0 || rule.Window <= 0 {
+			return nil, fmt.Errorf("규칙 %s: Threshold는 0 이상, Window는 0보다 커야 합니다", rule.Name)
+		}
+		if rule.Cooldown <= 0 {
+			rule.Cooldown = rule.Window
+		}
+		if rule.Samples <= 0 {
+			rule.Samples = defaultAlertSamples
+		}
+		if rule.Severity == "" {
+			rule.Severity = alert.SeverityWarning
+		}
+		a.rules = append(a.rules, &ruleState{rule: rule})
+	}
+	return a, nil
+}
+
+// Observe dead letter 하나 기록 (알림 전송은 백그라운드로)
+func (a *DeadLetterAlerter) Observe(msg amqp.Delivery) {
+	origin, _ := originOf(msg)
+	now := time.Now()
+
+	id := msg.MessageId
+	if id == "" {
+		id = msg.CorrelationId
+	}
+	if id == "" {
+		id = "(message_id 없음)"
+	}
+
+	for _, state := range a.rules {
+		if fired, ok := state.observe(origin, id, now); ok {
+			go a.send(fired)
+		}
+	}
+}
+
+// Middleware 핸들러 전에 dead letter를 기록하는 미들웨어
+func (a *DeadLetterAlerter) Middleware() Middleware {
+	return func(next ContextHandler) ContextHandler {
+		return func(ctx context.Context, delivery amqp.Delivery) error {
+			a.Observe(delivery)
+			return next(ctx, delivery)
+		}
+	}
+}
+
+func (a *DeadLetterAlerter) send(fired alert.Alert) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	log.Printf("[🚨] %s", fired.Title)
+	if err := a.sink.Send(ctx, fired); err != nil {
+		log.Printf("[❌] 알림 전송 실패 (%s): %v", fired.Key, err)
+	}
+}
+
+// observe 규칙에 해당하면 기록하고, 알림을 보내야 하면 그 알림을 반환
+func (s *ruleState) observe(origin deathOrigin, id string, now time.Time) (alert.Alert, bool) {
+	rule := s.rule
+	if rule.Queue != "" && origin.queue != rule.Queue {
+		return alert.Alert{}, false
+	}
+	if rule.Reason != "" && origin.reason != rule.Reason {
+		return alert.Alert{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 집계 구간을 벗어난 기록 제거
+	cutoff := now.Add(-rule.Window)
+	kept := s.events[:0]
+	for _, e := range s.events {
+		if e.at.After(cutoff) {
+			kept = append(kept, e)
+		}
+	}
+	s.events = append(kept, ruleEvent{at: now, messageID: id})
+
+	if len(s.events) <= rule.Threshold || now.Sub(s.lastFired) < rule.Cooldown {
+		return alert.Alert{}, false
+	}
+	s.lastFired = now
+
+	// 가장 최근 메시지를 샘플로
+	samples := make([]string, 0, rule.Samples)
+	for i := len(s.events) - 1; i >= 0 && len(samples) < rule.Samples; i-- {
+		samples = append(samples, s.events[i].messageID)
+	}
+
+	queue := rule.Queue
+	if queue == "" {
+		queue = "전체 큐"
+	}
+	labels := map[string]string{"rule": rule.Name, "queue": queue}
+	if rule.Reason != "" {
+		labels["reason"] = rule.Reason
+	}
+
+	return alert.Alert{
+		Key:   "dlq:" + rule.Name,
+		Title: fmt.Sprintf("Dead letter 급증: %s (%s 동안 %d건)", queue, rule.Window, len(s.events)),
+		Message: fmt.Sprintf("%s 동안 dead letter %d건 (기준: %d건 초과). %s 동안 같은 알림은 다시 보내지 않습니다.",
+			rule.Window, len(s.events), rule.Threshold, rule.Cooldown),
+		Severity:  rule.Severity,
+		Labels:    labels,
+		Count:     len(s.events),
+		Samples:   samples,
+		Timestamp: now,
+	}, true
+}
@@ -0,0 +1,114 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DedupStore 처리 완료된 메시지 키 저장소
+type DedupStore interface {
+	// Seen TTL이 지나지 않은 처리 기록이 있는지 확인
+	Seen(key string) (bool, error)
+	// Mark 처리 완료 기록 (ttl 후 만료)
+	Mark(key string, ttl time.Duration) error
+	Close() error
+}
+
+// KeyFunc 메시지에서 중복 판별 키를 추출하는 함수 타입
+type KeyFunc func(delivery amqp.Delivery) (string, error)
+
+// MessageIDKey AMQP MessageId 속성을 키로 사용
+func MessageIDKey() KeyFunc {
+	return func(delivery amqp.Delivery) (string, error) {
+		if delivery.MessageId == "" {
+			return "", errors.New("MessageId가 비어 있습니다")
+		}
+		return delivery.MessageId, nil
+	}
+}
+
+// HeaderKey 지정한 헤더 값을 키로 사용
+func HeaderKey(name string) KeyFunc {
+	return func(delivery amqp.Delivery) (string, error) {
+		value, ok := delivery.Headers[name]
+		if !ok || value == nil {
+			return "", fmt.Errorf("헤더가 없습니다: %s", name)
+		}
+		return fmt.Sprint(value), nil
+	}
+}
+
+// BodyFieldKey JSON 본문의 필드 값을 키로 사용 (중첩 필드는 "a.b.c" 형태)
+func BodyFieldKey(path string) KeyFunc {
+	fields := strings.Split(path, ".")
+	return func(delivery amqp.Delivery) (string, error) {
+		var current interface{}
+		if err := json.Unmarshal(delivery.Body, &current); err != nil {
+			return "", fmt.Errorf("본문 JSON 파싱 실패: %w", err)
+		}
+		for _, field := range fields {
+			obj, ok := current.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("본문 필드가 없습니다: %s", path)
+			}
+			if current, ok = obj[field]; !ok || current == nil {
+				return "", fmt.Errorf("본문 필드가 없습니다: %s", path)
+			}
+		}
+		switch v := current.(type) {
+		case string:
+			return v, nil
+		case map[string]interface{}, []interface{}:
+			return "", fmt.Errorf("본문 필드가 스칼라 값이 아닙니다: %s", path)
+		default:
+			return fmt.Sprint(v), nil
+		}
+	}
+}
+
+// Idempotent 이미 처리된 메시지를 건너뛰는 미들웨어
+// 핸들러가 성공한 경우에만 처리 완료로 기록하므로 실패한 메시지는 재전달 시 다시 처리된다.
+// 키를 추출할 수 없는 메시지는 중복 검사 없이 처리한다.
+// HandlerTimeout이 지난 뒤 끝난 처리는 이미 dead-letter/재큐잉 되었으므로 기록하지 않는다.
+func Idempotent(store DedupStore, key KeyFunc, ttl time.Duration) Middleware {
+	return func(next ContextHandler) ContextHandler {
+		return func(ctx context.Context, delivery amqp.Delivery) error {
+			id, err := key(delivery)
+			if err != nil {
+				log.Printf("[⚠️] 중복 판별 키 추출 실패, 중복 검사 생략: %v", err)
+				return next(ctx, delivery)
+			}
+
+			seen, err := store.Seen(id)
+			if err != nil {
+				return fmt.Errorf("중복 저장소 조회 실패: %w", err)
+			}
+			if seen {
+				log.Printf("[🔁] 이미 처리된 메시지, 건너뜀: %s", id)
+				return nil
+			}
+
+			if err := next(ctx, delivery); err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				// 기록하면 replay된 메시지가 중복으로 처리되어 건너뛰어진다
+				log.Printf("[⚠️] 제한 시간이 지난 뒤 처리 완료, 기록하지 않음 (%s): %v", id, err)
+				return err
+			}
+
+			if err := store.Mark(id, ttl); err != nil {
+				// 처리 자체는 성공했으므로 ACK하고 기록 실패만 남긴다
+				log.Printf("[⚠️] 처리 완료 기록 실패 (%s): %v", id, err)
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestKeyFuncs(t *testing.T) {
+	msg := amqp.Delivery{
+		MessageId: "m-1",
+		Headers:   amqp.Table{"x-order-id": int64(42)},
+		Body:      []byte(`{"order":{"id":"o-1","amount":1200,"items":[1]},"note":null}`),
+	}
+
+	tests := []struct {
+		name    string
+		key     KeyFunc
+		msg     amqp.Delivery
+		want    string
+		wantErr bool
+	}{
+		{"message ID", MessageIDKey(), msg, "m-1", false},
+		{"empty message ID", MessageIDKey(), amqp.Delivery{}, "", true},
+		{"header", HeaderKey("x-order-id"), msg, "42", false},
+		{"missing header", HeaderKey("x-missing"), msg, "", true},
+		{"nested body field", BodyFieldKey("order.id"), msg, "o-1", false},
+		{"number body field", BodyFieldKey("order.amount"), msg, "1200", false},
+		{"null body field", BodyFieldKey("note"), msg, "", true},
+		{"missing body field", BodyFieldKey("order.customer"), msg, "", true},
+		{"non-scalar body field", BodyFieldKey("order.items"), msg, "", true},
+		{"body not JSON", BodyFieldKey("id"), amqp.Delivery{Body: []byte("plain")}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.key(tt.msg)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("key() = %q, %v, want %q (error %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIdempotent(t *testing.T) {
+	store := NewMemoryDedupStore(10)
+	calls := 0
+	var result error
+	handler := Idempotent(store, MessageIDKey(), time.Hour)(func(context.Context, amqp.Delivery) error {
+		calls++
+		return result
+	})
+	ctx := context.Background()
+
+	// 실패하면 기록하지 않아 재전달 시 다시 처리한다
+	result = errors.New("boom")
+	if err := handler(ctx, amqp.Delivery{MessageId: "m-1"}); err == nil {
+		t.Fatal("handler error was not returned")
+	}
+	result = nil
+	handler(ctx, amqp.Delivery{MessageId: "m-1"})
+	handler(ctx, amqp.Delivery{MessageId: "m-1"})
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2 (failed, succeeded, then skipped)", calls)
+	}
+
+	// 키가 없는 메시지는 매번 처리
+	handler(ctx, amqp.Delivery{})
+	handler(ctx, amqp.Delivery{})
+	if calls != 4 {
+		t.Errorf("calls = %d, want 4", calls)
+	}
+
+	// 제한 시간이 지난 뒤 끝난 처리는 기록하지 않는다
+	expired, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := handler(expired, amqp.Delivery{MessageId: "m-2"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("handler after ctx done = %v, want context.Canceled", err)
+	}
+	if seen, _ := store.Seen("m-2"); seen {
+		t.Error("message finished after ctx done was marked as processed")
+	}
+}
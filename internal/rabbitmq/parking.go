@@ -0,0 +1,256 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// parking-lot으로 옮긴 메시지에 추가되는 헤더
+const (
+	HeaderParkedAt     = "x-parked-at"
+	HeaderParkedReason = "x-parked-reason"
+)
+
+// 기본 DLQ 검사 주기
+const defaultSweepInterval = time.Minute
+
+// ParkingConfig DLQ processor 설정
+type ParkingConfig struct {
+	Queue        string            // 검사할 DLQ
+	ParkingQueue string            // 오래되거나 반복 실패한 메시지를 옮길 큐
+	MaxAge       time.Duration     // dead-letter 된 지 이 시간이 지나면 이동 (0이면 검사 안 함)
+	MaxDeaths    int               // 누적 dead-letter 횟수가 이 값 이상이면 이동 (0이면 검사 안 함)
+	Interval     time.Duration     // 검사 주기 (기본 1분)
+	Archive      DeadLetterArchive // 검사한 모든 dead letter 보관 (nil이면 보관 안 함)
+
+	// 새로 보관된 dead letter마다 호출 (알림 집계 등, Archive 필요)
+	// DLQ를 검사할 때마다 같은 메시지를 다시 읽으므로 보관소로 처음 본 메시지만 구분한다.
+	OnDeadLetter func(amqp.Delivery)
+}
+
+// SweepResult DLQ 한 번 검사 결과
+type SweepResult struct {
+	Scanned  int
+	Archived int
+	Parked   int
+	Failed   int
+}
+
+// DeadLetterProcessor DLQ를 주기적으로 검사해 dead letter를 보관하고,
+// 기준을 넘은 메시지를 parking-lot 큐로 옮긴다. 나머지는 DLQ에 그대로 남겨 replay할 수 있게 한다.
+// confirm 모드는 채널 전체에 적용되므로 Connection의 공유 채널 대신 전용 채널을 열어 사용한다.
+type DeadLetterProcessor struct {
+	conn    *Connection
+	config  ParkingConfig
+	ch      *amqp.Channel // confirm 모드 전용 채널 (Close에서 닫음)
+	returns chan amqp.Return
+}
+
+// NewDeadLetterProcessor parking-lot 큐를 선언하고 processor 생성
+func NewDeadLetterProcessor(conn *Connection, config ParkingConfig) (*DeadLetterProcessor, error) {
+	if config.Queue == "" || config.ParkingQueue == "" {
+		return nil, errors.New("DLQ와 parking-lot 큐 이름이 모두 필요합니다")
+	}
+	if config.Queue == config.ParkingQueue {
+		return nil, errors.New("parking-lot 큐는 DLQ와 달라야 합니다")
+	}
+	if config.MaxAge < 0 || config.MaxDeaths < 0 {
+		return nil, errors.New("MaxAge와 MaxDeaths는 0 이상이어야 합니다")
+	}
+	if config.OnDeadLetter != nil && config.Archive == nil {
+		return nil, errors.New("OnDeadLetter를 사용하려면 Archive가 필요합니다")
+	}
+	if config.Interval <= 0 {
+		config.Interval = defaultSweepInterval
+	}
+
+	p := &DeadLetterProcessor{conn: conn, config: config}
+	if err := p.open(); err != nil {
+		return nil, err
+	}
+	if _, err := p.ch.QueueDeclare(config.ParkingQueue, true, false, false, false, nil); err != nil {
+		p.Close()
+		return nil, fmt.Errorf("parking-lot 큐 선언 실패: %w", err)
+	}
+	return p, nil
+}
+
+// open 전용 채널을 열고 confirm 모드 설정
+func (p *DeadLetterProcessor) open() error {
+	ch, err := p.conn.NewChannel()
+	if err != nil {
+		return err
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return fmt.Errorf("publisher confirm 설정 실패: %w", err)
+	}
+	p.ch = ch
+	p.returns = ch.NotifyReturn(make(chan amqp.Return, 1))
+	return nil
+}
+
+// Close 전용 채널 닫기
+func (p *DeadLetterProcessor) Close() error {
+	if p.ch == nil || p.ch.IsClosed() {
+		return nil
+	}
+	return p.ch.Close()
+}
+
+// Run ctx가 끝날 때까지 Interval마다 DLQ 검사
+func (p *DeadLetterProcessor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := p.Sweep(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[❌] DLQ 검사 실패: %v", err)
+		} else if result.Scanned > 0 {
+			log.Printf("[🧹] %s 검사: %d건 (보관 %d, parking %d, 실패 %d)",
+				p.config.Queue, result.Scanned, result.Archived, result.Parked, result.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep DLQ를 한 번 검사
+// 남겨 둘 메시지는 검사가 끝날 때까지 unacked로 잡아 두었다가 큐로 되돌린다.
+// 잡아 두는 메시지가 끝없이 늘지 않도록 검사 시작 시점의 메시지 수까지만 읽고,
+// 그 사이 새로 들어온 메시지는 다음 검사에서 처리한다.
+func (p *DeadLetterProcessor) Sweep(ctx context.Context) (SweepResult, error) {
+	var result SweepResult
+
+	// DLQ가 없는 등의 채널 오류로 닫혔으면 다시 연다
+	if p.ch.IsClosed() {
+		if err := p.open(); err != nil {
+			return result, err
+		}
+	}
+	ch := p.ch
+
+	queue, err := ch.QueueDeclarePassive(p.config.Queue, true, false, false, false, nil)
+	if err != nil {
+		return result, fmt.Errorf("DLQ 조회 실패: %w", err)
+	}
+	limit := queue.Messages
+
+	held := make([]amqp.Delivery, 0, limit)
+	defer func() {
+		for _, msg := range held {
+			msg.Nack(false, true)
+		}
+	}()
+
+	for result.Scanned < limit {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		msg, ok, err := ch.Get(p.config.Queue, false)
+		if err != nil {
+			return result, fmt.Errorf("DLQ 메시지 조회 실패: %w", err)
+		}
+		if !ok {
+			return result, nil
+		}
+		result.Scanned++
+
+		if p.config.Archive != nil {
+			added, err := p.config.Archive.Append(NewArchivedMessage(p.config.Queue, msg))
+			if err != nil {
+				// 보관되지 않은 메시지는 옮기지 않는다
+				log.Printf("[❌] dead letter 보관 실패: %v", err)
+				result.Failed++
+				held = append(held, msg)
+				continue
+			}
+			if added {
+				result.Archived++
+				if p.config.OnDeadLetter != nil {
+					p.config.OnDeadLetter(msg)
+				}
+			}
+		}
+
+		reason := p.parkReason(msg)
+		if reason == "" {
+			held = append(held, msg)
+			continue
+		}
+
+		if err := p.park(ctx, msg, reason); err != nil {
+			log.Printf("[❌] parking-lot 이동 실패: %v", err)
+			result.Failed++
+			held = append(held, msg)
+			continue
+		}
+		msg.Ack(false)
+		result.Parked++
+	}
+	return result, nil
+}
+
+// parkReason parking-lot으로 옮길 이유 (옮기지 않으면 빈 문자열)
+func (p *DeadLetterProcessor) parkReason(msg amqp.Delivery) string {
+	if p.config.MaxDeaths > 0 {
+		if deaths := deathCount(msg); deaths >= int64(p.config.MaxDeaths) {
+			return fmt.Sprintf("dead-letter %d회", deaths)
+		}
+	}
+	if p.config.MaxAge > 0 {
+		deadAt := msg.Timestamp
+		if origin, ok := originOf(msg); ok && !origin.time.IsZero() {
+			deadAt = origin.time
+		}
+		if !deadAt.IsZero() {
+			if age := time.Since(deadAt); age >= p.config.MaxAge {
+				return fmt.Sprintf("dead-letter 후 %s 경과", age.Truncate(time.Second))
+			}
+		}
+	}
+	return ""
+}
+
+// park 헤더를 유지한 채 parking-lot 큐로 발행 (x-death는 replay를 위해 남김)
+func (p *DeadLetterProcessor) park(ctx context.Context, msg amqp.Delivery, reason string) error {
+	headers := make(amqp.Table, len(msg.Headers)+2)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[HeaderParkedAt] = time.Now()
+	headers[HeaderParkedReason] = reason
+
+	publishing := publishingFrom(msg, headers)
+	return publishConfirmed(ctx, p.ch, "", p.config.ParkingQueue, publishing, p.returns)
+}
+
+// deathCount 누적 dead-letter 횟수
+// x-death 항목별 횟수의 합에, replay 때 지워진 x-death 대신 x-replay-count를 더한다.
+func deathCount(msg amqp.Delivery) int64 {
+	var total int64
+	deaths, _ := ParseDeaths(msg.Headers)
+	for _, death := range deaths {
+		total += death.Count
+	}
+	if len(deaths) == 0 {
+		if _, ok := msg.Headers[HeaderOriginalQueue]; ok {
+			total = 1
+		}
+	}
+	if replays, err := toInt64(msg.Headers[HeaderReplayCount]); err == nil {
+		total += replays
+	}
+	return total
+}
@@ -0,0 +1,125 @@
+package rabbitmq
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestDeathCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers amqp.Table
+		want    int64
+	}{
+		{"no headers", nil, 0},
+		{
+			name: "x-death counts are summed",
+			headers: amqp.Table{HeaderDeath: []interface{}{
+				amqp.Table{"count": int64(2), "queue": "orders.queue", "reason": "rejected"},
+				amqp.Table{"count": int64(1), "queue": "orders.retry", "reason": "expired"},
+			}},
+			want: 3,
+		},
+		{
+			name:    "consumer dead-letter without x-death counts once",
+			headers: amqp.Table{HeaderOriginalQueue: "orders.queue"},
+			want:    1,
+		},
+		{
+			name: "replay count is added",
+			headers: amqp.Table{
+				HeaderDeath:       []interface{}{amqp.Table{"count": int64(1), "queue": "orders.queue"}},
+				HeaderReplayCount: int32(2),
+			},
+			want: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deathCount(amqp.Delivery{Headers: tt.headers}); got != tt.want {
+				t.Errorf("deathCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParkReason(t *testing.T) {
+	now := time.Now()
+	death := func(count int64, at time.Time) amqp.Table {
+		return amqp.Table{HeaderDeath: []interface{}{amqp.Table{
+			"count": count, "queue": "orders.queue", "reason": "rejected",
+			"exchange": "orders", "routing-keys": []interface{}{"order.created"}, "time": at,
+		}}}
+	}
+
+	tests := []struct {
+		name   string
+		config ParkingConfig
+		msg    amqp.Delivery
+		want   string // 이유에 포함될 문자열 (빈 값이면 남겨 둠)
+	}{
+		{
+			name:   "below both limits",
+			config: ParkingConfig{MaxDeaths: 3, MaxAge: time.Hour},
+			msg:    amqp.Delivery{Headers: death(1, now.Add(-time.Minute))},
+		},
+		{
+			name:   "max deaths reached",
+			config: ParkingConfig{MaxDeaths: 3},
+			msg:    amqp.Delivery{Headers: death(3, now)},
+			want:   "3회",
+		},
+		{
+			name:   "older than max age",
+			config: ParkingConfig{MaxAge: time.Hour},
+			msg:    amqp.Delivery{Headers: death(1, now.Add(-2*time.Hour))},
+			want:   "경과",
+		},
+		{
+			name:   "message timestamp when there is no dead-letter time",
+			config: ParkingConfig{MaxAge: time.Hour},
+			msg:    amqp.Delivery{Timestamp: now.Add(-2 * time.Hour)},
+			want:   "경과",
+		},
+		{
+			name:   "checks disabled",
+			config: ParkingConfig{},
+			msg:    amqp.Delivery{Headers: death(10, now.Add(-24*time.Hour))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &DeadLetterProcessor{config: tt.config}
+			got := p.parkReason(tt.msg)
+			if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+				t.Errorf("parkReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDeadLetterProcessorValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ParkingConfig
+	}{
+		{"missing queues", ParkingConfig{Queue: "orders.dlq"}},
+		{"same queue", ParkingConfig{Queue: "orders.dlq", ParkingQueue: "orders.dlq"}},
+		{"negative max age", ParkingConfig{Queue: "orders.dlq", ParkingQueue: "orders.parking-lot", MaxAge: -time.Second}},
+		{"callback without archive", ParkingConfig{Queue: "orders.dlq", ParkingQueue: "orders.parking-lot", OnDeadLetter: func(amqp.Delivery) {}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 설정 검증은 채널을 열기 전에 끝나므로 연결 없이 확인할 수 있다
+			if _, err := NewDeadLetterProcessor(nil, tt.config); err == nil {
+				t.Error("NewDeadLetterProcessor() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package rabbitmq
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// deliverPartitioned 파티션 키 해시로 메시지를 worker에 분배해 병렬 처리
+// 같은 키의 메시지는 항상 같은 worker가 수신 순서대로 처리하므로 키 단위 순서가 보장된다.
+// 각 메시지는 개별 ACK(multiple=false)하므로 worker가 서로 다른 순서로 끝나도 안전하다.
+func (c *Consumer) deliverPartitioned(ctx context.Context, msgs <-chan amqp.Delivery, handler ContextHandler) error {
+	n := c.config.Partitions
+
+	// worker당 버퍼는 prefetch를 나눠 가진다 (prefetch 이상은 어차피 받을 수 없음)
+	buffer := 1
+	if prefetch := c.currentPrefetch(); prefetch > n {
+		buffer = prefetch / n
+	}
+
+	queues := make([]chan amqp.Delivery, n)
+	var wg sync.WaitGroup
+	for i := range queues {
+		queues[i] = make(chan amqp.Delivery, buffer)
+		wg.Add(1)
+		go func(queue <-chan amqp.Delivery) {
+			defer wg.Done()
+			for msg := range queue {
+				c.process(ctx, handler, msg)
+			}
+		}(queues[i])
+	}
+
+	// 종료 시 worker에 남은 메시지까지 정리될 때까지 기다린다
+	defer func() {
+		for _, queue := range queues {
+			close(queue)
+		}
+		wg.Wait()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-msgs:
+			if !ok {
+				return nil
+			}
+			c.trackOffset(msg)
+			select {
+			case queues[c.partition(msg)] <- msg:
+			case <-ctx.Done():
+				c.settle(msg, errRequeue)
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// partition 메시지의 파티션 번호 (키를 추출할 수 없으면 0번 파티션)
+func (c *Consumer) partition(msg amqp.Delivery) int {
+	key, err := c.config.PartitionKey(msg)
+	if err != nil {
+		log.Printf("[⚠️] 파티션 키 추출 실패, 0번 파티션으로 처리: %v", err)
+		return 0
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(c.config.Partitions))
+}
@@ -0,0 +1,96 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestPartition(t *testing.T) {
+	c := &Consumer{config: ConsumerConfig{Partitions: 4, PartitionKey: HeaderKey("x-customer")}}
+	msg := func(customer string) amqp.Delivery {
+		return amqp.Delivery{Headers: amqp.Table{"x-customer": customer}}
+	}
+
+	used := make(map[int]bool)
+	for i := 0; i < 50; i++ {
+		customer := fmt.Sprintf("c-%d", i)
+		p := c.partition(msg(customer))
+		if p < 0 || p >= 4 {
+			t.Fatalf("partition(%s) = %d, out of range", customer, p)
+		}
+		if again := c.partition(msg(customer)); again != p {
+			t.Errorf("partition(%s) changed: %d then %d", customer, p, again)
+		}
+		used[p] = true
+	}
+	if len(used) < 2 {
+		t.Errorf("50 keys went to %d partition(s), want them spread", len(used))
+	}
+
+	// 키가 없으면 0번 파티션
+	if p := c.partition(amqp.Delivery{}); p != 0 {
+		t.Errorf("partition(no key) = %d, want 0", p)
+	}
+}
+
+func TestDeliverPartitionedKeepsKeyOrder(t *testing.T) {
+	c := &Consumer{
+		queueName: "orders.queue",
+		prefetch:  8,
+		config:    ConsumerConfig{Partitions: 3, PartitionKey: HeaderKey("x-customer")},
+	}
+	ack := &recordingAcknowledger{}
+
+	var mu sync.Mutex
+	got := make(map[string][]int)
+	handler := func(_ context.Context, msg amqp.Delivery) error {
+		seq := int(msg.DeliveryTag)
+		// 먼저 온 메시지를 더 오래 처리해도 같은 키의 순서는 유지된다
+		time.Sleep(time.Duration(seq%3) * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		customer := msg.Headers["x-customer"].(string)
+		got[customer] = append(got[customer], seq)
+		return nil
+	}
+
+	const total = 30
+	customers := []string{"alice", "bob", "carol", "dave"}
+	want := make(map[string][]int)
+	msgs := make(chan amqp.Delivery, total)
+	for seq := 1; seq <= total; seq++ {
+		customer := customers[seq%len(customers)]
+		want[customer] = append(want[customer], seq)
+		msgs <- amqp.Delivery{
+			Acknowledger: ack,
+			DeliveryTag:  uint64(seq),
+			Headers:      amqp.Table{"x-customer": customer},
+		}
+	}
+	close(msgs)
+
+	// 구독이 끝나면 worker에 남은 메시지까지 처리한 뒤 반환
+	if err := c.deliverPartitioned(context.Background(), msgs, handler); err != nil {
+		t.Fatalf("deliverPartitioned() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("per-key order = %v, want %v", got, want)
+	}
+
+	ack.mu.Lock()
+	defer ack.mu.Unlock()
+	if len(ack.calls) != total {
+		t.Fatalf("%d acks, want %d", len(ack.calls), total)
+	}
+	for _, call := range ack.calls {
+		if call.op != "ack" || call.multiple {
+			t.Errorf("settle call = %+v, want single ack", call)
+		}
+	}
+}
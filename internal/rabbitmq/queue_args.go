@@ -0,0 +1,196 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// QueueType 큐 타입 (x-queue-type)
+type QueueType string
+
+const (
+	QueueTypeClassic QueueType = "classic"
+	QueueTypeQuorum  QueueType = "quorum"
+	QueueTypeStream  QueueType = "stream"
+)
+
+// OverflowMode 최대 길이 초과 시 동작 (x-overflow)
+type OverflowMode string
+
+const (
+	OverflowDropHead         OverflowMode = "drop-head"
+	OverflowRejectPublish    OverflowMode = "reject-publish"
+	OverflowRejectPublishDLX OverflowMode = "reject-publish-dlx"
+)
+
+// Validate 큐 설정 조합 검증 (브로커에서 PRECONDITION_FAILED로 채널이 닫히기 전에 확인)
+func (c ConsumerConfig) Validate() error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.QueueName == "" {
+		fail("QueueName이 비어 있습니다")
+	}
+
+	queueType := c.queueType()
+	switch queueType {
+	case QueueTypeClassic, QueueTypeQuorum, QueueTypeStream:
+	default:
+		fail("알 수 없는 큐 타입: %s", c.QueueType)
+	}
+
+	// 값 범위
+	if c.TTL < 0 {
+		fail("TTL은 0 이상이어야 합니다: %d", c.TTL)
+	}
+	if c.DeliveryLimit < 0 {
+		fail("DeliveryLimit은 0 이상이어야 합니다: %d", c.DeliveryLimit)
+	}
+	if c.MaxLength < 0 {
+		fail("MaxLength는 0 이상이어야 합니다: %d", c.MaxLength)
+	}
+	if c.MaxLengthBytes < 0 {
+		fail("MaxLengthBytes는 0 이상이어야 합니다: %d", c.MaxLengthBytes)
+	}
+	if c.MaxPriority < 0 || c.MaxPriority > 255 {
+		fail("MaxPriority는 0~255 범위여야 합니다: %d", c.MaxPriority)
+	}
+	if c.Expires < 0 {
+		fail("Expires는 0 이상이어야 합니다: %d", c.Expires)
+	}
+
+	// x-overflow
+	switch c.Overflow {
+	case "", OverflowDropHead, OverflowRejectPublish, OverflowRejectPublishDLX:
+	default:
+		fail("알 수 없는 overflow 모드: %s", c.Overflow)
+	}
+	if c.Overflow != "" && c.MaxLength == 0 && c.MaxLengthBytes == 0 {
+		fail("Overflow는 MaxLength 또는 MaxLengthBytes와 함께 설정해야 합니다")
+	}
+	if c.Overflow == OverflowRejectPublishDLX && c.DLQExchange == "" {
+		fail("reject-publish-dlx는 DLQExchange 설정이 필요합니다")
+	}
+
+	// DLQ 설정
+	if c.DLQExchange != "" && c.DLQQueue == "" {
+		fail("DLQExchange를 설정하면 DLQQueue도 필요합니다")
+	}
+
+	// 파티션 병렬 처리
+	if c.Partitions < 0 {
+		fail("Partitions는 0 이상이어야 합니다: %d", c.Partitions)
+	}
+	if c.Partitions > 1 && c.PartitionKey == nil {
+		fail("Partitions를 사용하려면 PartitionKey가 필요합니다")
+	}
+
+	// 바인딩
+	for i, b := range c.Bindings {
+		if b.Exchange == "" {
+			fail("Bindings[%d]: 기본 exchange에는 바인딩할 수 없습니다", i)
+		}
+	}
+
+	// 큐 타입별 제약
+	if c.DeliveryLimit > 0 && queueType != QueueTypeQuorum {
+		fail("DeliveryLimit은 quorum 큐에서만 사용할 수 있습니다")
+	}
+	if c.LazyMode && queueType != QueueTypeClassic {
+		fail("LazyMode는 classic 큐에서만 사용할 수 있습니다")
+	}
+	if c.MaxPriority > 0 && queueType != QueueTypeClassic {
+		fail("MaxPriority는 classic 큐에서만 사용할 수 있습니다")
+	}
+
+	switch queueType {
+	case QueueTypeQuorum:
+		if c.Overflow == OverflowRejectPublishDLX {
+			fail("quorum 큐는 reject-publish-dlx를 지원하지 않습니다")
+		}
+	case QueueTypeStream:
+		if c.DLQExchange != "" {
+			fail("stream 큐는 dead letter를 지원하지 않습니다")
+		}
+		if c.TTL > 0 {
+			fail("stream 큐는 메시지 TTL을 지원하지 않습니다")
+		}
+		if c.MaxLength > 0 {
+			fail("stream 큐는 MaxLength를 지원하지 않습니다 (MaxLengthBytes 사용)")
+		}
+		if c.Overflow != "" {
+			fail("stream 큐는 Overflow를 지원하지 않습니다")
+		}
+		if c.SingleActiveConsumer {
+			fail("stream 큐는 AMQP 0-9-1에서 SingleActiveConsumer를 지원하지 않습니다")
+		}
+		if c.Expires > 0 {
+			fail("stream 큐는 Expires를 지원하지 않습니다")
+		}
+		if c.BatchSize > 0 || c.BatchWait > 0 {
+			fail("stream 큐는 배치 모드를 지원하지 않습니다 (오프셋을 메시지 단위로 추적)")
+		}
+		if c.OffsetStore != nil && c.ConsumerName == "" {
+			fail("OffsetStore를 사용하려면 ConsumerName이 필요합니다")
+		}
+	default:
+		if !c.StreamOffset.IsZero() || c.OffsetStore != nil || c.OffsetCommitEvery != 0 || c.OffsetCommitInterval != 0 {
+			fail("StreamOffset/OffsetStore/OffsetCommit*는 stream 큐에서만 사용할 수 있습니다")
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("잘못된 큐 설정 (%s): %w", c.QueueName, errors.Join(errs...))
+	}
+	return nil
+}
+
+// queueType 설정된 큐 타입 (기본 classic)
+func (c ConsumerConfig) queueType() QueueType {
+	if c.QueueType == "" {
+		return QueueTypeClassic
+	}
+	return c.QueueType
+}
+
+// queueArgs 메인 큐 선언 인자 생성 (DLX 인자는 NewConsumer에서 추가)
+func (c ConsumerConfig) queueArgs() amqp.Table {
+	args := make(amqp.Table)
+
+	if c.QueueType != "" {
+		args["x-queue-type"] = string(c.QueueType)
+	}
+	if c.TTL > 0 {
+		args["x-message-ttl"] = c.TTL
+	}
+	if c.DeliveryLimit > 0 {
+		args["x-delivery-limit"] = c.DeliveryLimit
+	}
+	if c.MaxLength > 0 {
+		args["x-max-length"] = c.MaxLength
+	}
+	if c.MaxLengthBytes > 0 {
+		args["x-max-length-bytes"] = c.MaxLengthBytes
+	}
+	if c.Overflow != "" {
+		args["x-overflow"] = string(c.Overflow)
+	}
+	if c.MaxPriority > 0 {
+		args["x-max-priority"] = int32(c.MaxPriority)
+	}
+	if c.SingleActiveConsumer {
+		args["x-single-active-consumer"] = true
+	}
+	if c.Expires > 0 {
+		args["x-expires"] = c.Expires
+	}
+	if c.LazyMode {
+		args["x-queue-mode"] = "lazy"
+	}
+
+	return args
+}
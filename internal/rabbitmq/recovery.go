@@ -0,0 +1,171 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Dead letter에 추가되는 헤더 이름
+const (
+	HeaderError          = "x-error"
+	HeaderPanic          = "x-panic"
+	HeaderPanicStack     = "x-panic-stack"
+	HeaderHandlerTimeout = "x-handler-timeout"
+)
+
+// 직접 재발행한 dead letter의 원래 목적지 (브로커가 남기는 x-death 대신 사용)
+const (
+	HeaderOriginalExchange   = "x-original-exchange"
+	HeaderOriginalRoutingKey = "x-original-routing-key"
+	HeaderOriginalQueue      = "x-original-queue"
+	HeaderDeadLetteredAt     = "x-dead-lettered-at"
+)
+
+// 스택 트레이스 헤더 최대 길이 (AMQP frame 크기 보호)
+const maxStackHeaderSize = 8 * 1024
+
+// errRequeue 메시지를 DLQ 대신 큐로 되돌려야 할 때 사용 (예: 종료 중 취소)
+var errRequeue = errors.New("메시지 재큐잉 필요")
+
+// DeadLetterError 추가 헤더와 함께 dead-letter 처리되어야 하는 에러
+type DeadLetterError struct {
+	Err     error
+	Headers amqp.Table
+}
+
+// NewDeadLetterError 헤더를 포함한 dead-letter 에러 생성
+func NewDeadLetterError(err error, headers amqp.Table) *DeadLetterError {
+	return &DeadLetterError{Err: err, Headers: headers}
+}
+
+func (e *DeadLetterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DeadLetterError) Unwrap() error {
+	return e.Err
+}
+
+// invoke 핸들러를 panic 복구 및 제한 시간과 함께 실행
+func (c *Consumer) invoke(ctx context.Context, handler ContextHandler, msg amqp.Delivery) error {
+	return c.guard(ctx, func(ctx context.Context) error {
+		return handler(ctx, msg)
+	})
+}
+
+// guard fn을 별도 goroutine에서 실행하며 panic을 dead-letter 에러로 변환하고 HandlerTimeout을 적용
+func (c *Consumer) guard(ctx context.Context, fn func(ctx context.Context) error) error {
+	if c.config.HandlerTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.HandlerTimeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				stack := debug.Stack()
+				if len(stack) > maxStackHeaderSize {
+					stack = stack[:maxStackHeaderSize]
+				}
+				log.Printf("[💥] 핸들러 panic 복구: %v", r)
+				done <- NewDeadLetterError(fmt.Errorf("핸들러 panic: %v", r), amqp.Table{
+					HeaderPanic:      fmt.Sprint(r),
+					HeaderPanicStack: string(stack),
+				})
+			}
+		}()
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		// 핸들러 goroutine은 계속 실행될 수 있지만 prefetch 슬롯은 즉시 반환한다
+		c.abandon(done)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return NewDeadLetterError(fmt.Errorf("핸들러 처리 시간 초과 (%s)", c.config.HandlerTimeout), amqp.Table{
+				HeaderHandlerTimeout: c.config.HandlerTimeout.String(),
+			})
+		}
+		return fmt.Errorf("%w: %v", errRequeue, ctx.Err())
+	}
+}
+
+// abandon 결과를 기다리지 않기로 한 핸들러 goroutine을 끝날 때까지 집계
+// goroutine을 강제로 멈출 수는 없으므로, ctx를 무시하는 핸들러가 쌓이는 것을 로그와 Status로 드러낸다.
+func (c *Consumer) abandon(done <-chan error) {
+	n := c.abandoned.Add(1)
+	log.Printf("[⚠️] 핸들러가 ctx 종료 후에도 실행 중입니다 (%s, 미종료 %d개)", c.queueName, n)
+	go func() {
+		<-done
+		n := c.abandoned.Add(-1)
+		log.Printf("[ℹ️] 포기한 핸들러 종료 (%s, 미종료 %d개)", c.queueName, n)
+	}()
+}
+
+// deadLetter 추가 헤더와 함께 메시지를 DLX로 재발행 후 원본 ACK
+// DLX가 설정되지 않았으면 일반 NACK로 처리한다 (헤더는 유실됨)
+func (c *Consumer) deadLetter(msg amqp.Delivery, dlErr *DeadLetterError) {
+	if c.config.DLQExchange == "" {
+		msg.Nack(false, false)
+		return
+	}
+
+	headers := make(amqp.Table, len(msg.Headers)+len(dlErr.Headers)+5)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	for k, v := range dlErr.Headers {
+		headers[k] = v
+	}
+	headers[HeaderError] = dlErr.Error()
+	headers[HeaderOriginalExchange] = msg.Exchange
+	headers[HeaderOriginalRoutingKey] = msg.RoutingKey
+	headers[HeaderOriginalQueue] = c.queueName
+	headers[HeaderDeadLetteredAt] = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := c.conn.Channel().PublishWithContext(
+		ctx,
+		c.config.DLQExchange,
+		c.queueName, // DLQ routing key = 원본 큐 이름
+		false,
+		false,
+		publishingFrom(msg, headers),
+	)
+	if err != nil {
+		log.Printf("[❌] DLX 재발행 실패, NACK로 대체: %v", err)
+		msg.Nack(false, false)
+		return
+	}
+	msg.Ack(false)
+}
+
+// publishingFrom 수신한 메시지의 속성을 유지한 재발행 메시지 (UserId는 발행 연결과 달라질 수 있어 제외)
+func publishingFrom(msg amqp.Delivery, headers amqp.Table) amqp.Publishing {
+	return amqp.Publishing{
+		Headers:         headers,
+		ContentType:     msg.ContentType,
+		ContentEncoding: msg.ContentEncoding,
+		DeliveryMode:    amqp.Persistent,
+		Priority:        msg.Priority,
+		CorrelationId:   msg.CorrelationId,
+		ReplyTo:         msg.ReplyTo,
+		MessageId:       msg.MessageId,
+		Timestamp:       msg.Timestamp,
+		Type:            msg.Type,
+		AppId:           msg.AppId,
+		Body:            msg.Body,
+	}
+}
@@ -0,0 +1,50 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGuard(t *testing.T) {
+	c := &Consumer{queueName: "orders.queue", config: ConsumerConfig{HandlerTimeout: time.Second}}
+	errBoom := errors.New("boom")
+
+	if err := c.guard(context.Background(), func(context.Context) error { return errBoom }); err != errBoom {
+		t.Errorf("guard(error) = %v, want %v", err, errBoom)
+	}
+
+	err := c.guard(context.Background(), func(context.Context) error { panic("nil map") })
+	var dlErr *DeadLetterError
+	if !errors.As(err, &dlErr) || dlErr.Headers[HeaderPanic] != "nil map" {
+		t.Errorf("guard(panic) = %v, want dead-letter error with %s header", err, HeaderPanic)
+	}
+}
+
+func TestGuardCountsAbandonedHandlers(t *testing.T) {
+	c := &Consumer{queueName: "orders.queue", config: ConsumerConfig{HandlerTimeout: 10 * time.Millisecond}}
+
+	// ctx를 무시하는 핸들러는 시간 초과 뒤에도 release 전까지 끝나지 않는다
+	release := make(chan struct{})
+	err := c.guard(context.Background(), func(context.Context) error {
+		<-release
+		return nil
+	})
+	var dlErr *DeadLetterError
+	if !errors.As(err, &dlErr) || dlErr.Headers[HeaderHandlerTimeout] == nil {
+		t.Fatalf("guard() = %v, want handler timeout dead-letter error", err)
+	}
+	if got := c.Status().AbandonedHandlers; got != 1 {
+		t.Fatalf("AbandonedHandlers = %d, want 1", got)
+	}
+
+	close(release)
+	deadline := time.Now().Add(5 * time.Second)
+	for c.Status().AbandonedHandlers != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("AbandonedHandlers = %d after the handler returned, want 0", c.Status().AbandonedHandlers)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,360 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// HeaderReplayCount DLQ에서 재발행된 횟수 (재처리 루프 확인용)
+const HeaderReplayCount = "x-replay-count"
+
+// 재발행 시 제거하는 dead-letter 관련 헤더
+var replayStripHeaders = []string{
+	HeaderDeath,
+	HeaderError,
+	HeaderPanic,
+	HeaderPanicStack,
+	HeaderHandlerTimeout,
+	HeaderValidationErrors,
+	HeaderOriginalExchange,
+	HeaderOriginalRoutingKey,
+	HeaderOriginalQueue,
+	HeaderDeadLetteredAt,
+}
+
+// 재발행 시 제거하는 브로커 헤더 접두사 (x-first-death-queue 등)
+var replayStripPrefixes = []string{"x-first-death-", "x-last-death-"}
+
+// ReplayAction 메시지별 replay 처리 결과
+type ReplayAction string
+
+const (
+	ReplayPublished ReplayAction = "replayed" // 원래 목적지로 재발행 후 DLQ에서 제거
+	ReplayDryRun    ReplayAction = "dry-run"  // 재발행 대상 (dry-run이라 큐에 남김)
+	ReplaySkipped   ReplayAction = "skipped"  // 필터 불일치 또는 목적지 불명, 큐에 남김
+	ReplayFailed    ReplayAction = "failed"   // 재발행 실패, 큐에 남김
+)
+
+// ReplayFilter 재발행할 dead letter 조건 (비어 있는 조건은 검사하지 않음)
+type ReplayFilter struct {
+	Reasons []string          // dead-letter 사유 (rejected, expired, maxlen, delivery_limit)
+	Queues  []string          // dead-letter 되기 전 큐
+	Since   time.Time         // 이 시각 이후 dead-letter 된 메시지
+	Until   time.Time         // 이 시각 이전 dead-letter 된 메시지
+	Headers map[string]string // 헤더 값 일치 (문자열로 비교)
+}
+
+// ReplayConfig DLQ replay 설정
+type ReplayConfig struct {
+	Queue     string       // 읽어 올 DLQ
+	Filter    ReplayFilter // 재발행 조건
+	DryRun    bool         // 재발행하지 않고 대상만 확인
+	Rate      float64      // 초당 최대 재발행 수 (0이면 제한 없음)
+	MaxCount  int          // 최대 재발행 수 (0이면 제한 없음)
+	OnMessage func(ReplayRecord)
+}
+
+// ReplayRecord 메시지 하나의 replay 결과
+type ReplayRecord struct {
+	Delivery    amqp.Delivery
+	Exchange    string   // 원래 exchange
+	RoutingKeys []string // 원래 routing key (첫 번째 외에는 CC로 재발행)
+	Queue       string   // dead-letter 되기 전 큐
+	Reason      string   // dead-letter 사유
+	Action      ReplayAction
+	Err         error
+}
+
+// ReplayResult replay 실행 결과 (DryRun이면 Replayed는 재발행 대상 수)
+type ReplayResult struct {
+	Scanned  int
+	Replayed int
+	Skipped  int
+	Failed   int
+}
+
+// Replayer DLQ 메시지를 x-death 헤더의 원래 목적지로 재발행
+// 재발행하지 않는 메시지는 실행이 끝날 때까지 unacked로 잡아 두었다가 큐로 되돌리므로
+// 같은 메시지를 반복해서 읽지 않는다. 실행 중에는 이 메시지들을 다른 Consumer가 받을 수 없다.
+type Replayer struct {
+	conn   *Connection
+	config ReplayConfig
+}
+
+// NewReplayer DLQ Replayer 생성
+func NewReplayer(conn *Connection, config ReplayConfig) (*Replayer, error) {
+	if config.Queue == "" {
+		return nil, errors.New("replay할 큐 이름이 필요합니다")
+	}
+	if config.Rate < 0 {
+		return nil, fmt.Errorf("Rate는 0 이상이어야 합니다: %v", config.Rate)
+	}
+	if config.MaxCount < 0 {
+		return nil, fmt.Errorf("MaxCount는 0 이상이어야 합니다: %d", config.MaxCount)
+	}
+	return &Replayer{conn: conn, config: config}, nil
+}
+
+// Run 시작 시점의 DLQ 메시지 수만큼 (또는 MaxCount까지) 읽어 재발행
+// 실행 중 새로 dead-letter 된 메시지(재발행 후 다시 실패한 메시지 포함)는 읽지 않으므로
+// 잡아 두는 메시지 수가 시작 시점의 큐 길이를 넘지 않는다.
+func (r *Replayer) Run(ctx context.Context) (ReplayResult, error) {
+	var result ReplayResult
+	ch := r.conn.Channel()
+
+	queue, err := ch.QueueDeclarePassive(r.config.Queue, true, false, false, false, nil)
+	if err != nil {
+		return result, fmt.Errorf("DLQ 조회 실패: %w", err)
+	}
+	limit := queue.Messages
+
+	var returns chan amqp.Return
+	if !r.config.DryRun {
+		if err := ch.Confirm(false); err != nil {
+			return result, fmt.Errorf("publisher confirm 설정 실패: %w", err)
+		}
+		returns = ch.NotifyReturn(make(chan amqp.Return, 1))
+	}
+
+	// 재발행하지 않은 메시지는 끝날 때 한꺼번에 큐로 되돌린다
+	held := make([]amqp.Delivery, 0, limit)
+	defer func() {
+		for _, msg := range held {
+			msg.Nack(false, true)
+		}
+	}()
+
+	var limiter <-chan time.Time
+	if r.config.Rate > 0 && !r.config.DryRun {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / r.config.Rate))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	for result.Scanned < limit && (r.config.MaxCount == 0 || result.Replayed < r.config.MaxCount) {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		msg, ok, err := ch.Get(r.config.Queue, false)
+		if err != nil {
+			return result, fmt.Errorf("DLQ 메시지 조회 실패: %w", err)
+		}
+		if !ok {
+			break
+		}
+		result.Scanned++
+
+		record := ReplayRecord{Delivery: msg}
+		origin, found := originOf(msg)
+		if found {
+			record.Exchange = origin.exchange
+			record.RoutingKeys = origin.routingKeys
+			record.Queue = origin.queue
+			record.Reason = origin.reason
+		}
+
+		switch {
+		case !found:
+			record.Action = ReplaySkipped
+			record.Err = errors.New("x-death 헤더가 없어 원래 목적지를 알 수 없습니다")
+		case !r.config.Filter.match(msg, origin):
+			record.Action = ReplaySkipped
+		case r.config.DryRun:
+			record.Action = ReplayDryRun
+		default:
+			if limiter != nil {
+				select {
+				case <-ctx.Done():
+					held = append(held, msg)
+					return result, ctx.Err()
+				case <-limiter:
+				}
+			}
+			if err := r.republish(ctx, msg, origin, returns); err != nil {
+				record.Action = ReplayFailed
+				record.Err = err
+			} else {
+				record.Action = ReplayPublished
+			}
+		}
+
+		switch record.Action {
+		case ReplayPublished:
+			result.Replayed++
+			msg.Ack(false)
+		case ReplayDryRun:
+			result.Replayed++
+			held = append(held, msg)
+		case ReplaySkipped:
+			result.Skipped++
+			held = append(held, msg)
+		case ReplayFailed:
+			result.Failed++
+			held = append(held, msg)
+		}
+
+		if r.config.OnMessage != nil {
+			r.config.OnMessage(record)
+		}
+	}
+
+	return result, nil
+}
+
+// republish 원래 exchange/routing key로 재발행하고 broker 확인까지 대기
+func (r *Replayer) republish(ctx context.Context, msg amqp.Delivery, origin deathOrigin, returns <-chan amqp.Return) error {
+	// mandatory: 라우팅되지 않으면 basic.return으로 돌아오므로 유실 없이 실패 처리
+	return publishConfirmed(ctx, r.conn.Channel(), origin.exchange, origin.routingKeys[0],
+		replayPublishing(msg, origin), returns)
+}
+
+// publishConfirmed confirm 모드 채널에 발행하고 브로커 확인까지 대기
+// returns가 주어지면 mandatory로 발행하고, 라우팅되지 않아 돌아온 메시지는 실패로 처리한다.
+func publishConfirmed(ctx context.Context, ch *amqp.Channel, exchange, key string, msg amqp.Publishing, returns <-chan amqp.Return) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, returns != nil, false, msg)
+	if err != nil {
+		return fmt.Errorf("재발행 실패: %w", err)
+	}
+
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("재발행 확인 대기 실패: %w", err)
+	}
+	if !acked {
+		return errors.New("브로커가 재발행을 거부했습니다 (nack)")
+	}
+
+	// basic.return은 confirm보다 먼저 도착한다
+	select {
+	case ret := <-returns:
+		return fmt.Errorf("라우팅할 큐가 없습니다 (exchange=%q, routing key=%q): %s",
+			ret.Exchange, ret.RoutingKey, ret.ReplyText)
+	default:
+		return nil
+	}
+}
+
+// replayPublishing dead-letter 헤더를 정리한 재발행 메시지
+func replayPublishing(msg amqp.Delivery, origin deathOrigin) amqp.Publishing {
+	headers := make(amqp.Table, len(msg.Headers)+2)
+	for k, v := range msg.Headers {
+		if !stripOnReplay(k) {
+			headers[k] = v
+		}
+	}
+
+	count, _ := headerInt(msg.Headers[HeaderReplayCount])
+	headers[HeaderReplayCount] = int32(count + 1)
+
+	// 처음 발행 때 CC로 받은 routing key도 다시 전달
+	if len(origin.routingKeys) > 1 {
+		cc := make([]interface{}, 0, len(origin.routingKeys)-1)
+		for _, key := range origin.routingKeys[1:] {
+			cc = append(cc, key)
+		}
+		headers["CC"] = cc
+	}
+
+	publishing := publishingFrom(msg, headers)
+	publishing.Expiration = origin.expiration
+	return publishing
+}
+
+func stripOnReplay(header string) bool {
+	if header == "CC" || header == "BCC" {
+		return true
+	}
+	for _, h := range replayStripHeaders {
+		if header == h {
+			return true
+		}
+	}
+	for _, prefix := range replayStripPrefixes {
+		if strings.HasPrefix(header, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// deathOrigin dead letter의 원래 목적지와 사유
+type deathOrigin struct {
+	exchange    string
+	routingKeys []string
+	queue       string
+	reason      string
+	time        time.Time
+	expiration  string // 메시지 TTL (브로커가 dead-letter 시 제거한 값)
+}
+
+// originOf 원래 목적지 추출
+// 브로커가 dead-letter 한 메시지는 가장 최근 x-death 항목을, Consumer가 직접 재발행한 메시지는
+// x-original-* 헤더를 사용한다.
+func originOf(msg amqp.Delivery) (deathOrigin, bool) {
+	if death, ok, _ := LatestDeath(msg.Headers); ok && len(death.RoutingKeys) > 0 {
+		return deathOrigin{
+			exchange:    death.Exchange,
+			routingKeys: death.RoutingKeys,
+			queue:       death.Queue,
+			reason:      death.Reason,
+			time:        death.Time,
+			expiration:  death.OriginalExpiration,
+		}, true
+	}
+
+	exchange, ok := msg.Headers[HeaderOriginalExchange].(string)
+	if !ok {
+		return deathOrigin{}, false
+	}
+	routingKey, _ := msg.Headers[HeaderOriginalRoutingKey].(string)
+	queue, _ := msg.Headers[HeaderOriginalQueue].(string)
+	at, _ := msg.Headers[HeaderDeadLetteredAt].(time.Time)
+	return deathOrigin{
+		exchange:    exchange,
+		routingKeys: []string{routingKey},
+		queue:       queue,
+		reason:      "rejected",
+		time:        at,
+	}, true
+}
+
+// match 필터 조건 확인
+func (f ReplayFilter) match(msg amqp.Delivery, origin deathOrigin) bool {
+	if len(f.Reasons) > 0 && !contains(f.Reasons, origin.reason) {
+		return false
+	}
+	if len(f.Queues) > 0 && !contains(f.Queues, origin.queue) {
+		return false
+	}
+	if !f.Since.IsZero() && origin.time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !origin.time.Before(f.Until) {
+		return false
+	}
+	for name, want := range f.Headers {
+		value, ok := msg.Headers[name]
+		if !ok || fmt.Sprint(value) != want {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
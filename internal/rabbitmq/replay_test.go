@@ -0,0 +1,129 @@
+package rabbitmq
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestOriginOf(t *testing.T) {
+	deadAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	earlier := deadAt.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		headers amqp.Table
+		want    deathOrigin
+		wantOK  bool
+	}{
+		{
+			name: "latest x-death entry wins",
+			headers: amqp.Table{HeaderDeath: []interface{}{
+				amqp.Table{
+					"exchange": "orders.exchange", "routing-keys": []interface{}{"order.paid", "audit.order"},
+					"queue": "orders.queue", "reason": "expired", "time": deadAt,
+					"count": int64(2), "original-expiration": "60000",
+				},
+				amqp.Table{
+					"exchange": "old.exchange", "routing-keys": []interface{}{"old"},
+					"queue": "old.queue", "reason": "rejected", "time": earlier, "count": int64(1),
+				},
+			}},
+			want: deathOrigin{
+				exchange: "orders.exchange", routingKeys: []string{"order.paid", "audit.order"},
+				queue: "orders.queue", reason: "expired", time: deadAt, expiration: "60000",
+			},
+			wantOK: true,
+		},
+		{
+			name: "x-original headers from consumer dead-letter",
+			headers: amqp.Table{
+				HeaderOriginalExchange:   "orders.exchange",
+				HeaderOriginalRoutingKey: "order.created",
+				HeaderOriginalQueue:      "orders.queue",
+				HeaderDeadLetteredAt:     deadAt,
+			},
+			want: deathOrigin{
+				exchange: "orders.exchange", routingKeys: []string{"order.created"},
+				queue: "orders.queue", reason: "rejected", time: deadAt,
+			},
+			wantOK: true,
+		},
+		{
+			name: "x-death without routing keys falls back to x-original headers",
+			headers: amqp.Table{
+				HeaderDeath:              []interface{}{amqp.Table{"queue": "orders.queue", "reason": "rejected"}},
+				HeaderOriginalExchange:   "",
+				HeaderOriginalRoutingKey: "orders.queue",
+				HeaderOriginalQueue:      "orders.queue",
+			},
+			want: deathOrigin{
+				exchange: "", routingKeys: []string{"orders.queue"},
+				queue: "orders.queue", reason: "rejected",
+			},
+			wantOK: true,
+		},
+		{
+			name:    "no origin headers",
+			headers: amqp.Table{"x-custom": "value"},
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := originOf(amqp.Delivery{Headers: tt.headers})
+			if ok != tt.wantOK {
+				t.Fatalf("originOf ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("originOf = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayFilterMatch(t *testing.T) {
+	deadAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	msg := amqp.Delivery{Headers: amqp.Table{"tenant": "acme", "attempt": int32(3)}}
+	origin := deathOrigin{queue: "orders.queue", reason: "rejected", time: deadAt}
+
+	tests := []struct {
+		name   string
+		filter ReplayFilter
+		want   bool
+	}{
+		{"empty filter matches everything", ReplayFilter{}, true},
+		{"reason match", ReplayFilter{Reasons: []string{"expired", "rejected"}}, true},
+		{"reason mismatch", ReplayFilter{Reasons: []string{"expired"}}, false},
+		{"queue match", ReplayFilter{Queues: []string{"orders.queue"}}, true},
+		{"queue mismatch", ReplayFilter{Queues: []string{"payments.queue"}}, false},
+		{"since is inclusive", ReplayFilter{Since: deadAt}, true},
+		{"before since", ReplayFilter{Since: deadAt.Add(time.Second)}, false},
+		{"until is exclusive", ReplayFilter{Until: deadAt}, false},
+		{"before until", ReplayFilter{Until: deadAt.Add(time.Second)}, true},
+		{"header match", ReplayFilter{Headers: map[string]string{"tenant": "acme"}}, true},
+		{"non-string header compared as text", ReplayFilter{Headers: map[string]string{"attempt": "3"}}, true},
+		{"header mismatch", ReplayFilter{Headers: map[string]string{"tenant": "other"}}, false},
+		{"missing header", ReplayFilter{Headers: map[string]string{"region": "kr"}}, false},
+		{
+			"all conditions",
+			ReplayFilter{
+				Reasons: []string{"rejected"},
+				Queues:  []string{"orders.queue"},
+				Since:   deadAt.Add(-time.Hour),
+				Until:   deadAt.Add(time.Hour),
+				Headers: map[string]string{"tenant": "acme"},
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.match(msg, origin); got != tt.want {
+			t.Errorf("%s: match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Router routing key의 topic 패턴별로 핸들러를 분기하는 디스패처
+// 하나의 큐에 여러 이벤트 타입이 바인딩되어 있을 때 사용한다.
+type Router struct {
+	mu       sync.RWMutex
+	routes   []route
+	fallback ContextHandler
+}
+
+type route struct {
+	pattern string
+	words   []string
+	handler ContextHandler
+}
+
+// NewRouter 새 라우터 생성
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle topic 패턴에 핸들러 등록
+// 패턴 문법은 topic exchange와 같다: "*"는 단어 하나, "#"는 0개 이상의 단어.
+// 여러 패턴이 일치하면 먼저 등록된 핸들러가 사용된다.
+func (r *Router) Handle(pattern string, handler MessageHandler) *Router {
+	return r.HandleContext(pattern, withoutContext(handler))
+}
+
+// HandleContext context를 받는 핸들러 등록 (DispatchContext로 소비할 때 HandlerTimeout이 전달됨)
+func (r *Router) HandleContext(pattern string, handler ContextHandler) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.routes = append(r.routes, route{
+		pattern: pattern,
+		words:   topicWords(pattern),
+		handler: handler,
+	})
+	return r
+}
+
+// Fallback 일치하는 패턴이 없을 때 사용할 핸들러 등록
+// nil을 반환하면 메시지가 ACK되므로, 놓치면 안 되는 이벤트는 에러를 반환해 dead-letter로 보낸다.
+func (r *Router) Fallback(handler MessageHandler) *Router {
+	return r.FallbackContext(withoutContext(handler))
+}
+
+// FallbackContext context를 받는 fallback 핸들러 등록
+func (r *Router) FallbackContext(handler ContextHandler) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fallback = handler
+	return r
+}
+
+// Dispatch 메시지를 routing key에 맞는 핸들러로 전달 (MessageHandler로 사용)
+// 일치하는 핸들러도 fallback도 없으면 에러를 반환해 dead-letter 처리되게 한다.
+func (r *Router) Dispatch(delivery amqp.Delivery) error {
+	return r.DispatchContext(context.Background(), delivery)
+}
+
+// DispatchContext ConsumeContext용 디스패치 (ctx를 핸들러까지 그대로 전달)
+func (r *Router) DispatchContext(ctx context.Context, delivery amqp.Delivery) error {
+	handler := r.lookup(delivery.RoutingKey)
+	if handler == nil {
+		return fmt.Errorf("routing key에 맞는 핸들러가 없습니다: %s", delivery.RoutingKey)
+	}
+	return handler(ctx, delivery)
+}
+
+// withoutContext ctx를 쓰지 않는 핸들러를 ContextHandler로 변환
+func withoutContext(handler MessageHandler) ContextHandler {
+	return func(_ context.Context, delivery amqp.Delivery) error {
+		return handler(delivery)
+	}
+}
+
+// lookup routing key에 맞는 핸들러 조회
+func (r *Router) lookup(routingKey string) ContextHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keyWords := topicWords(routingKey)
+	for _, rt := range r.routes {
+		if matchWords(rt.words, keyWords) {
+			return rt.handler
+		}
+	}
+	return r.fallback
+}
+
+// MatchTopic routing key가 topic 패턴과 일치하는지 확인
+func MatchTopic(pattern, routingKey string) bool {
+	return matchWords(topicWords(pattern), topicWords(routingKey))
+}
+
+// topicWords "."로 단어 분리 (빈 문자열은 단어 0개, "a..b"의 가운데처럼 빈 단어는 그대로 단어 하나)
+func topicWords(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ".")
+}
+
+// matchWords 단어 단위 topic 매칭 ("#"는 0개 이상의 단어와 일치)
+func matchWords(pattern, key []string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case "#":
+			// 연속된 "#"는 하나와 같다
+			for len(pattern) > 0 && pattern[0] == "#" {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := 0; i <= len(key); i++ {
+				if matchWords(pattern, key[i:]) {
+					return true
+				}
+			}
+			return false
+		case "*":
+			if len(key) == 0 {
+				return false
+			}
+		default:
+			if len(key) == 0 || pattern[0] != key[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		key = key[1:]
+	}
+	return len(key) == 0
+}
@@ -0,0 +1,111 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"order.created", "order.created", true},
+		{"order.created", "order.paid", false},
+		{"order.created", "order.created.v2", false},
+
+		// "*"는 정확히 한 단어
+		{"order.*", "order.paid", true},
+		{"order.*", "order", false},
+		{"order.*", "order.paid.v2", false},
+		{"*.cancelled", "order.cancelled", true},
+		{"*", "", false},
+
+		// "#"는 0개 이상의 단어
+		{"#", "", true},
+		{"#", "order.paid.v2", true},
+		{"order.#", "order", true},
+		{"order.#", "order.paid.v2", true},
+		{"order.#", "payment.paid", false},
+		{"order.#.#", "order", true},
+		{"#.x", "x", true},
+		{"#.x", "a.b.x", true},
+		{"#.x", "x.a", false},
+		{"a.#.z", "a.z", true},
+		{"a.#.z", "a.b.c.z", true},
+		{"a.#.z", "a.b.c", false},
+		{"#.*", "", false},
+		{"#.*", "a", true},
+
+		// 빈 단어도 단어 하나
+		{"a..b", "a..b", true},
+		{"a.*.b", "a..b", true},
+		{"a.b", "a..b", false},
+		{"a.*", "a.", true},
+		{"a.#", "a.", true},
+		{"", "", true},
+		{"", "a", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchTopic(tt.pattern, tt.key); got != tt.want {
+			t.Errorf("MatchTopic(%q, %q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestRouterDispatch(t *testing.T) {
+	var got string
+	handler := func(name string) MessageHandler {
+		return func(amqp.Delivery) error {
+			got = name
+			return nil
+		}
+	}
+	router := NewRouter().
+		Handle("order.created", handler("created")).
+		Handle("*.cancelled", handler("cancelled")).
+		Handle("order.#", handler("order"))
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"order.created", "created"},
+		{"order.cancelled", "cancelled"}, // 먼저 등록된 패턴 우선
+		{"order.paid", "order"},
+		{"order.shipped.express", "order"},
+	}
+	for _, tt := range tests {
+		got = ""
+		if err := router.Dispatch(amqp.Delivery{RoutingKey: tt.key}); err != nil {
+			t.Errorf("Dispatch(%q) error: %v", tt.key, err)
+		}
+		if got != tt.want {
+			t.Errorf("Dispatch(%q) handled by %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	if err := router.Dispatch(amqp.Delivery{RoutingKey: "payment.paid"}); err == nil {
+		t.Error("Dispatch without matching route or fallback: want error")
+	}
+}
+
+func TestRouterDispatchContext(t *testing.T) {
+	type ctxKey struct{}
+	router := NewRouter().HandleContext("order.#", func(ctx context.Context, _ amqp.Delivery) error {
+		if ctx.Value(ctxKey{}) != "deadline" {
+			return errors.New("handler did not receive dispatch ctx")
+		}
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "deadline")
+	if err := router.DispatchContext(ctx, amqp.Delivery{RoutingKey: "order.paid"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,100 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"rabbit-mq-with-go/internal/schema"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// 스키마 정보 헤더 (schema-web 게시 API와 동일)
+const (
+	HeaderSchemaName    = "schema_name"
+	HeaderSchemaVersion = "schema_version"
+)
+
+// 검증 실패 dead letter에 추가되는 헤더
+const HeaderValidationErrors = "x-validation-errors"
+
+// MissingSchemaPolicy 검증할 스키마를 찾을 수 없는 메시지 처리 정책
+// schema_name 헤더가 없거나, 이 Consumer의 레지스트리에 없는 스키마/버전인 경우에 적용된다.
+// (발행 측 레지스트리에서 스키마가 새 버전으로 올라가도 Consumer가 멀쩡한 메시지를 버리지 않도록)
+type MissingSchemaPolicy int
+
+const (
+	MissingSchemaAllow  MissingSchemaPolicy = iota // 검증 없이 핸들러로 전달
+	MissingSchemaReject                            // dead-letter 처리
+	MissingSchemaSkip                              // 핸들러를 건너뛰고 ACK
+)
+
+// SchemaValidator 헤더의 스키마로 본문을 검증하는 미들웨어
+// schema_version 헤더가 있으면 해당 버전으로, 없으면 현재 버전으로 검증한다.
+// 검증에 실패한 메시지는 x-validation-errors 헤더와 함께 dead-letter 처리된다.
+func SchemaValidator(registry *schema.SchemaRegistry, missing MissingSchemaPolicy) Middleware {
+	return func(next ContextHandler) ContextHandler {
+		return func(ctx context.Context, delivery amqp.Delivery) error {
+			// 검증할 스키마가 없을 때 정책 적용
+			unknown := func(reason string) error {
+				switch missing {
+				case MissingSchemaReject:
+					return NewDeadLetterError(errors.New(reason), amqp.Table{
+						HeaderValidationErrors: []interface{}{reason},
+					})
+				case MissingSchemaSkip:
+					log.Printf("[ℹ️] %s, 건너뜀", reason)
+					return nil
+				default:
+					return next(ctx, delivery)
+				}
+			}
+
+			name, ok := delivery.Headers[HeaderSchemaName].(string)
+			if !ok || name == "" {
+				return unknown("schema_name 헤더가 없습니다")
+			}
+			if _, err := registry.Get(name); err != nil {
+				log.Printf("[⚠️] 등록되지 않은 스키마: %s", name)
+				return unknown(fmt.Sprintf("등록되지 않은 스키마: %s", name))
+			}
+
+			var result schema.ValidationResult
+			if raw, ok := delivery.Headers[HeaderSchemaVersion]; ok {
+				version, err := headerInt(raw)
+				if err != nil {
+					return NewDeadLetterError(fmt.Errorf("잘못된 schema_version 헤더: %w", err), amqp.Table{
+						HeaderValidationErrors: []interface{}{err.Error()},
+					})
+				}
+				if _, err := registry.GetVersion(name, version); err != nil {
+					log.Printf("[⚠️] 알 수 없는 스키마 버전: %s v%d", name, version)
+					return unknown(fmt.Sprintf("알 수 없는 스키마 버전: %s v%d", name, version))
+				}
+				result = registry.ValidateVersion(name, version, delivery.Body)
+			} else {
+				result = registry.Validate(name, delivery.Body)
+			}
+
+			if !result.Valid {
+				errs := make([]interface{}, len(result.Errors))
+				for i, e := range result.Errors {
+					errs[i] = e
+				}
+				return NewDeadLetterError(fmt.Errorf("스키마 검증 실패 (%s): %s", name, result.Message), amqp.Table{
+					HeaderValidationErrors: errs,
+				})
+			}
+
+			return next(ctx, delivery)
+		}
+	}
+}
+
+// headerInt AMQP 헤더 값을 int로 변환 (발행 측에 따라 정수 타입이 다를 수 있음)
+func headerInt(value interface{}) (int, error) {
+	n, err := toInt64(value)
+	return int(n), err
+}
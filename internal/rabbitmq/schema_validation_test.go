@@ -0,0 +1,87 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"rabbit-mq-with-go/internal/schema"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func newTestRegistry(t *testing.T) *schema.SchemaRegistry {
+	t.Helper()
+	registry := schema.NewSchemaRegistry()
+	if err := registry.Register("OrderEvent", schema.SchemaTypeJSON, schema.OrderEventSchema, nil); err != nil {
+		t.Fatal(err)
+	}
+	return registry
+}
+
+// runValidator 미들웨어를 한 번 실행해 핸들러 호출 여부와 에러 반환
+func runValidator(registry *schema.SchemaRegistry, missing MissingSchemaPolicy, delivery amqp.Delivery) (bool, error) {
+	called := false
+	handler := SchemaValidator(registry, missing)(func(context.Context, amqp.Delivery) error {
+		called = true
+		return nil
+	})
+	err := handler(context.Background(), delivery)
+	return called, err
+}
+
+const validOrder = `{"order_id":"ORD-1","customer_id":"C-1","amount":1000,"status":"created","created_at":"2026-10-01T00:00:00Z"}`
+
+func TestSchemaValidatorUnknownVersion(t *testing.T) {
+	registry := newTestRegistry(t)
+	// 발행 측 레지스트리는 v3까지 올라갔지만 이 Consumer는 v1만 알고 있음
+	delivery := amqp.Delivery{
+		Headers: amqp.Table{HeaderSchemaName: "OrderEvent", HeaderSchemaVersion: int32(3)},
+		Body:    []byte(validOrder),
+	}
+
+	called, err := runValidator(registry, MissingSchemaAllow, delivery)
+	if err != nil || !called {
+		t.Fatalf("allow: called=%v err=%v, want handler called without error", called, err)
+	}
+
+	called, err = runValidator(registry, MissingSchemaSkip, delivery)
+	if err != nil || called {
+		t.Fatalf("skip: called=%v err=%v, want skipped without error", called, err)
+	}
+
+	called, err = runValidator(registry, MissingSchemaReject, delivery)
+	var dlErr *DeadLetterError
+	if called || !errors.As(err, &dlErr) {
+		t.Fatalf("reject: called=%v err=%v, want dead-letter error", called, err)
+	}
+}
+
+func TestSchemaValidator(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	tests := []struct {
+		name       string
+		headers    amqp.Table
+		body       string
+		wantCalled bool
+		wantDL     bool
+	}{
+		{"valid current version", amqp.Table{HeaderSchemaName: "OrderEvent"}, validOrder, true, false},
+		{"valid explicit version", amqp.Table{HeaderSchemaName: "OrderEvent", HeaderSchemaVersion: int64(1)}, validOrder, true, false},
+		{"invalid body", amqp.Table{HeaderSchemaName: "OrderEvent"}, `{"order_id":"ORD-1"}`, false, true},
+		{"bad version header", amqp.Table{HeaderSchemaName: "OrderEvent", HeaderSchemaVersion: "v1"}, validOrder, false, true},
+		{"missing schema header", amqp.Table{}, `not json`, true, false},
+		{"unknown schema name", amqp.Table{HeaderSchemaName: "ShipmentEvent"}, `{}`, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called, err := runValidator(registry, MissingSchemaAllow, amqp.Delivery{Headers: tt.headers, Body: []byte(tt.body)})
+			var dlErr *DeadLetterError
+			if called != tt.wantCalled || errors.As(err, &dlErr) != tt.wantDL {
+				t.Errorf("called=%v err=%v, want called=%v dead-letter=%v", called, err, tt.wantCalled, tt.wantDL)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package rabbitmq
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// stream 큐에서 prefetch를 지정하지 않았을 때 사용할 값 (stream은 QoS 필수)
+const defaultStreamPrefetch = 100
+
+// 오프셋 저장 주기 기본값
+const (
+	defaultOffsetCommitEvery    = 100
+	defaultOffsetCommitInterval = time.Second
+)
+
+// StreamOffset stream 큐의 소비 시작 위치 (x-stream-offset)
+type StreamOffset struct {
+	spec   string    // "first", "last", "next"
+	offset int64     // spec이 "offset"일 때
+	at     time.Time // spec이 "timestamp"일 때
+}
+
+// OffsetFirst 스트림의 첫 메시지부터
+func OffsetFirst() StreamOffset { return StreamOffset{spec: "first"} }
+
+// OffsetLast 마지막 chunk부터
+func OffsetLast() StreamOffset { return StreamOffset{spec: "last"} }
+
+// OffsetNext 구독 이후 새로 들어오는 메시지부터
+func OffsetNext() StreamOffset { return StreamOffset{spec: "next"} }
+
+// OffsetAt 지정한 숫자 오프셋부터
+func OffsetAt(offset int64) StreamOffset { return StreamOffset{spec: "offset", offset: offset} }
+
+// OffsetTimestamp 지정한 시각 이후 저장된 메시지부터
+func OffsetTimestamp(at time.Time) StreamOffset { return StreamOffset{spec: "timestamp", at: at} }
+
+// IsZero 시작 위치가 지정되지 않았는지 확인
+func (o StreamOffset) IsZero() bool {
+	return o.spec == ""
+}
+
+func (o StreamOffset) String() string {
+	switch o.spec {
+	case "offset":
+		return fmt.Sprintf("offset=%d", o.offset)
+	case "timestamp":
+		return "timestamp=" + o.at.Format(time.RFC3339)
+	case "":
+		return "next"
+	default:
+		return o.spec
+	}
+}
+
+// arg basic.consume 인자로 사용할 값
+func (o StreamOffset) arg() interface{} {
+	switch o.spec {
+	case "offset":
+		return o.offset
+	case "timestamp":
+		return o.at // AMQP timestamp로 인코딩됨
+	case "":
+		return "next"
+	default:
+		return o.spec
+	}
+}
+
+// OffsetStore 마지막으로 처리한 stream 오프셋 저장소
+type OffsetStore interface {
+	// Load 저장된 오프셋 조회 (없으면 ok=false)
+	Load(name string) (offset int64, ok bool, err error)
+	Save(name string, offset int64) error
+}
+
+// MemoryOffsetStore 메모리 오프셋 저장소 (재시작 시 유실)
+type MemoryOffsetStore struct {
+	mu      sync.Mutex
+	offsets map[string]int64
+}
+
+// NewMemoryOffsetStore 메모리 오프셋 저장소 생성
+func NewMemoryOffsetStore() *MemoryOffsetStore {
+	return &MemoryOffsetStore{offsets: make(map[string]int64)}
+}
+
+func (s *MemoryOffsetStore) Load(name string) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	offset, ok := s.offsets[name]
+	return offset, ok, nil
+}
+
+func (s *MemoryOffsetStore) Save(name string, offset int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offsets[name] = offset
+	return nil
+}
+
+var offsetBucket = []byte("stream_offsets")
+
+// BoltOffsetStore bbolt 파일 기반 오프셋 저장소
+type BoltOffsetStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltOffsetStore 파일 오프셋 저장소 열기 (없으면 생성)
+func OpenBoltOffsetStore(path string) (*BoltOffsetStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("오프셋 저장소 열기 실패: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(offsetBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("오프셋 저장소 초기화 실패: %w", err)
+	}
+	return &BoltOffsetStore{db: db}, nil
+}
+
+func (s *BoltOffsetStore) Load(name string) (int64, bool, error) {
+	var offset int64
+	var ok bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(offsetBucket).Get([]byte(name))
+		if len(value) == 8 {
+			offset = int64(binary.BigEndian.Uint64(value))
+			ok = true
+		}
+		return nil
+	})
+	return offset, ok, err
+}
+
+func (s *BoltOffsetStore) Save(name string, offset int64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(offset))
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(offsetBucket).Put([]byte(name), value)
+	})
+}
+
+func (s *BoltOffsetStore) Close() error {
+	return s.db.Close()
+}
+
+// isStream stream 큐 소비 모드인지 확인
+func (c *Consumer) isStream() bool {
+	return c.config.queueType() == QueueTypeStream
+}
+
+// streamArgs 저장된 오프셋 다음부터, 없으면 설정된 시작 위치부터 구독하는 consume 인자
+func (c *Consumer) streamArgs() (amqp.Table, error) {
+	start := c.config.StreamOffset
+	if c.config.OffsetStore != nil {
+		// 아직 저장하지 않은 오프셋부터 저장해야 이미 처리한 메시지를 다시 받지 않는다
+		c.flushOffset()
+
+		offset, ok, err := c.config.OffsetStore.Load(c.tag)
+		if err != nil {
+			return nil, fmt.Errorf("stream 오프셋 조회 실패: %w", err)
+		}
+		if ok {
+			start = OffsetAt(offset + 1)
+		}
+	}
+
+	log.Printf("[🌊] %s stream 구독 시작 위치: %s", c.queueName, start)
+	return amqp.Table{"x-stream-offset": start.arg()}, nil
+}
+
+// trackOffset 수신 순서대로 오프셋 등록 (처리 전에 호출)
+func (c *Consumer) trackOffset(msg amqp.Delivery) {
+	if c.offsets == nil || c.config.OffsetStore == nil {
+		return
+	}
+	if offset, ok := deliveryOffset(msg); ok {
+		c.offsets.add(offset)
+	}
+}
+
+// commitOffset 처리한 메시지까지 연속으로 완료된 가장 큰 stream 오프셋 저장
+// 파티션 worker가 순서와 다르게 끝나도 앞선 메시지가 끝나기 전에는 저장하지 않는다.
+func (c *Consumer) commitOffset(msg amqp.Delivery) {
+	if c.offsets == nil || c.config.OffsetStore == nil {
+		return
+	}
+	offset, ok := deliveryOffset(msg)
+	if !ok {
+		log.Printf("[⚠️] x-stream-offset 헤더가 없어 오프셋을 저장하지 못했습니다")
+		return
+	}
+
+	commit, ok := c.offsets.done(offset)
+	if !ok {
+		return
+	}
+	c.committer.commit(commit)
+}
+
+// flushOffset 저장하지 않은 오프셋이 있으면 바로 저장 (종료, 재구독 시)
+func (c *Consumer) flushOffset() {
+	if c.committer != nil {
+		c.committer.flush()
+	}
+}
+
+// offsetCommitter 연속 완료된 오프셋을 모아 두었다가 N개마다 또는 일정 시간이 지나면 저장
+// bbolt 같은 파일 저장소는 저장할 때마다 fsync하므로 메시지마다 저장하면 처리량이 크게 떨어진다.
+// 재시작하면 마지막 저장 이후의 메시지는 다시 받으므로 핸들러는 멱등이어야 한다.
+type offsetCommitter struct {
+	store    OffsetStore
+	name     string
+	every    int
+	interval time.Duration
+
+	mu      sync.Mutex
+	offset  int64       // 저장할 오프셋
+	pending int         // 마지막 저장 이후 완료된 메시지 수 (0이면 저장할 것 없음)
+	timer   *time.Timer // interval 후 저장 예약
+}
+
+func newOffsetCommitter(store OffsetStore, name string, every int, interval time.Duration) *offsetCommitter {
+	if every <= 0 {
+		every = defaultOffsetCommitEvery
+	}
+	if interval <= 0 {
+		interval = defaultOffsetCommitInterval
+	}
+	return &offsetCommitter{store: store, name: name, every: every, interval: interval}
+}
+
+// commit 연속 완료된 오프셋 기록, every개가 모였으면 바로 저장하고 아니면 interval 후 저장 예약
+func (oc *offsetCommitter) commit(offset int64) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	oc.offset = offset
+	oc.pending++
+	if oc.pending >= oc.every {
+		oc.save()
+		return
+	}
+	if oc.timer == nil {
+		oc.timer = time.AfterFunc(oc.interval, oc.flush)
+	}
+}
+
+// flush 저장하지 않은 오프셋이 있으면 저장
+func (oc *offsetCommitter) flush() {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.save()
+}
+
+// save 오프셋 저장 (oc.mu를 잡은 상태로 호출, 저장 순서가 뒤바뀌지 않도록 잠금 안에서 저장)
+func (oc *offsetCommitter) save() {
+	if oc.timer != nil {
+		oc.timer.Stop()
+		oc.timer = nil
+	}
+	if oc.pending == 0 {
+		return
+	}
+	if err := oc.store.Save(oc.name, oc.offset); err != nil {
+		// 다음 commit이나 flush에서 다시 시도
+		log.Printf("[⚠️] stream 오프셋 저장 실패 (%d): %v", oc.offset, err)
+		return
+	}
+	oc.pending = 0
+}
+
+// offsetTracker 수신 순서 기준으로 연속 완료된 오프셋 계산
+type offsetTracker struct {
+	mu       sync.Mutex
+	pending  []int64        // 수신 순서대로의 미완료 오프셋
+	finished map[int64]bool // 완료됐지만 앞선 오프셋이 남아 있는 것
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{finished: make(map[int64]bool)}
+}
+
+func (t *offsetTracker) add(offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, offset)
+}
+
+// done 오프셋 완료 표시, 새로 저장할 오프셋이 생겼으면 반환
+func (t *offsetTracker) done(offset int64) (int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.finished[offset] = true
+
+	var commit int64
+	advanced := false
+	for len(t.pending) > 0 && t.finished[t.pending[0]] {
+		commit = t.pending[0]
+		delete(t.finished, commit)
+		t.pending = t.pending[1:]
+		advanced = true
+	}
+	return commit, advanced
+}
+
+// deliveryOffset 브로커가 붙여주는 x-stream-offset 헤더 값
+func deliveryOffset(msg amqp.Delivery) (int64, bool) {
+	switch v := msg.Headers["x-stream-offset"].(type) {
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
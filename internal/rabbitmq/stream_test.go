@@ -0,0 +1,146 @@
+package rabbitmq
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOffsetTrackerDone(t *testing.T) {
+	type step struct {
+		done       int64
+		wantCommit int64
+		wantOK     bool
+	}
+	tests := []struct {
+		name     string
+		received []int64
+		steps    []step
+	}{
+		{
+			name:     "in order",
+			received: []int64{1, 2, 3},
+			steps:    []step{{1, 1, true}, {2, 2, true}, {3, 3, true}},
+		},
+		{
+			name:     "out of order waits for the oldest",
+			received: []int64{1, 2, 3},
+			steps:    []step{{3, 0, false}, {2, 0, false}, {1, 3, true}},
+		},
+		{
+			name:     "gap in the middle",
+			received: []int64{10, 11, 12, 13},
+			steps:    []step{{10, 10, true}, {12, 0, false}, {13, 0, false}, {11, 13, true}},
+		},
+		{
+			name:     "offsets with holes follow receive order",
+			received: []int64{5, 9, 20},
+			steps:    []step{{9, 0, false}, {5, 9, true}, {20, 20, true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newOffsetTracker()
+			for _, offset := range tt.received {
+				tracker.add(offset)
+			}
+			for i, s := range tt.steps {
+				commit, ok := tracker.done(s.done)
+				if ok != s.wantOK || (ok && commit != s.wantCommit) {
+					t.Fatalf("step %d: done(%d) = (%d, %v), want (%d, %v)", i, s.done, commit, ok, s.wantCommit, s.wantOK)
+				}
+			}
+			if len(tracker.pending) != 0 || len(tracker.finished) != 0 {
+				t.Errorf("tracker not drained: pending=%v finished=%v", tracker.pending, tracker.finished)
+			}
+		})
+	}
+}
+
+// recordingOffsetStore Save 호출을 순서대로 기록 (fail이 true면 저장 실패)
+type recordingOffsetStore struct {
+	mu    sync.Mutex
+	saves []int64
+	fail  bool
+}
+
+func (s *recordingOffsetStore) Load(string) (int64, bool, error) { return 0, false, nil }
+
+func (s *recordingOffsetStore) Save(_ string, offset int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		return errors.New("disk full")
+	}
+	s.saves = append(s.saves, offset)
+	return nil
+}
+
+func (s *recordingOffsetStore) saved() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.saves...)
+}
+
+func TestOffsetCommitterEvery(t *testing.T) {
+	store := &recordingOffsetStore{}
+	oc := newOffsetCommitter(store, "orders.stream", 3, time.Hour)
+
+	for offset := int64(1); offset <= 7; offset++ {
+		oc.commit(offset)
+	}
+	if got := store.saved(); !reflect.DeepEqual(got, []int64{3, 6}) {
+		t.Fatalf("saves after 7 commits = %v, want [3 6]", got)
+	}
+
+	// 종료 시 남은 오프셋 저장, 저장할 것이 없으면 다시 저장하지 않는다
+	oc.flush()
+	oc.flush()
+	if got := store.saved(); !reflect.DeepEqual(got, []int64{3, 6, 7}) {
+		t.Errorf("saves after flush = %v, want [3 6 7]", got)
+	}
+}
+
+func TestOffsetCommitterInterval(t *testing.T) {
+	store := &recordingOffsetStore{}
+	oc := newOffsetCommitter(store, "orders.stream", 100, 10*time.Millisecond)
+
+	oc.commit(1)
+	oc.commit(2)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(store.saved()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("offset was not saved after the interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := store.saved(); !reflect.DeepEqual(got, []int64{2}) {
+		t.Errorf("saves = %v, want [2]", got)
+	}
+}
+
+func TestOffsetCommitterRetriesFailedSave(t *testing.T) {
+	store := &recordingOffsetStore{fail: true}
+	oc := newOffsetCommitter(store, "orders.stream", 1, time.Hour)
+
+	oc.commit(1)
+	store.mu.Lock()
+	store.fail = false
+	store.mu.Unlock()
+
+	// 실패한 오프셋은 버리지 않고 flush에서 다시 저장
+	oc.flush()
+	if got := store.saved(); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("saves = %v, want [1]", got)
+	}
+}
+
+func TestNewOffsetCommitterDefaults(t *testing.T) {
+	oc := newOffsetCommitter(&recordingOffsetStore{}, "orders.stream", 0, 0)
+	if oc.every != defaultOffsetCommitEvery || oc.interval != defaultOffsetCommitInterval {
+		t.Errorf("every = %d, interval = %v, want defaults", oc.every, oc.interval)
+	}
+}
@@ -259,6 +259,37 @@ func (r *SchemaRegistry) Validate(schemaName string, data []byte) ValidationResu
 		}
 	}
 
+	return r.validate(schema, data)
+}
+
+// ValidateVersion 특정 버전의 스키마로 데이터 검증
+func (r *SchemaRegistry) ValidateVersion(schemaName string, version int, data []byte) ValidationResult {
+	schema, err := r.Get(schemaName)
+	if err != nil {
+		return ValidationResult{
+			Valid:   false,
+			Errors:  []string{err.Error()},
+			Message: "스키마 조회 실패",
+		}
+	}
+
+	v, err := r.GetVersion(schemaName, version)
+	if err != nil {
+		return ValidationResult{
+			Valid:   false,
+			Errors:  []string{err.Error()},
+			Message: "스키마 버전 조회 실패",
+		}
+	}
+
+	// 버전 히스토리에는 타입이 없으므로 현재 스키마 타입을 사용
+	schema.Version = v.Version
+	schema.Schema = v.Schema
+	return r.validate(schema, data)
+}
+
+// validate 스키마 타입별 검증
+func (r *SchemaRegistry) validate(schema SchemaInfo, data []byte) ValidationResult {
 	switch schema.Type {
 	case SchemaTypeJSON:
 		return r.validateJSON(schema, data)